@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// archiveTarGz is the format for gzip compressed tar archives.
+	archiveTarGz = "tar.gz"
+	// archiveZip is the format for zip archives.
+	archiveZip = "zip"
+
+	// _archiveName is the default template for the name of an archive.
+	_archiveName = "{{ .Name }}.{{ .Format }}"
+)
+
+var (
+	// ErrorInvalidArchiveFormat is returned when the archive format is unsupported.
+	ErrorInvalidArchiveFormat = errors.New("invalid archive format provided")
+
+	// _archiveTime is the fixed modification time for archive entries,
+	// the earliest time representable in the zip format.
+	_archiveTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// validateArchive is a helper function to verify the archive format is supported.
+func validateArchive(format string) error {
+	switch format {
+	case "", archiveTarGz, archiveZip:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s (Valid formats: %s, %s)", ErrorInvalidArchiveFormat, format, archiveTarGz, archiveZip)
+	}
+}
+
+// archiveName is a helper function to render the name of
+// the archive for the provided directory from the template.
+func archiveName(text, dir, format, tag string) (string, error) {
+	if len(text) == 0 {
+		text = _archiveName
+	}
+
+	tmpl, err := template.New("archive_name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: archive_name: %w", ErrorInvalidTemplate, err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, struct {
+		Format string
+		Name   string
+		Tag    string
+	}{
+		Format: format,
+		Name:   filepath.Base(dir),
+		Tag:    tag,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: archive_name: %w", ErrorInvalidTemplate, err)
+	}
+
+	return buf.String(), nil
+}
+
+// archiveMode is a helper function to normalize the permissions
+// of an archive entry so archives don't depend on the umask.
+func archiveMode(info fs.FileInfo) fs.FileMode {
+	if info.IsDir() {
+		return 0755
+	}
+
+	// keep files executable when any execute bit is set
+	if info.Mode().Perm()&0111 != 0 {
+		return 0755
+	}
+
+	return 0644
+}
+
+// archiveDir creates a reproducible archive of the provided directory
+// at dst, with sorted entries, fixed times and normalized permissions.
+func archiveDir(dir, dst, format string) error {
+	logrus.Debugf("archiving %s to %s", dir, dst)
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch format {
+	case archiveTarGz:
+		err = writeTarGz(dir, f)
+	case archiveZip:
+		err = writeZip(dir, f)
+	default:
+		err = validateArchive(format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// walkArchive is a helper function to call fn for the regular files and
+// directories under dir in lexical order with their slash separated name.
+func walkArchive(dir string, fn func(path, name string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// skip the root directory itself
+		if path == dir {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			logrus.Warnf("skipping %s in archive: not a regular file", path)
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(path, filepath.ToSlash(rel), info)
+	})
+}
+
+// writeTarGz is a helper function to write a gzip compressed tar archive of dir to w.
+func writeTarGz(dir string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := walkArchive(dir, func(path, name string, info fs.FileInfo) error {
+		hdr := &tar.Header{
+			Mode:    int64(archiveMode(info)),
+			ModTime: _archiveTime,
+			Name:    name,
+		}
+
+		if info.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+
+			return tw.WriteHeader(hdr)
+		}
+
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+
+		err := tw.WriteHeader(hdr)
+		if err != nil {
+			return err
+		}
+
+		return copyFile(tw, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// writeZip is a helper function to write a zip archive of dir to w.
+func writeZip(dir string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	err := walkArchive(dir, func(path, name string, info fs.FileInfo) error {
+		hdr := &zip.FileHeader{
+			Method:   zip.Deflate,
+			Modified: _archiveTime,
+			Name:     name,
+		}
+
+		hdr.SetMode(archiveMode(info))
+
+		if info.IsDir() {
+			hdr.Method = zip.Store
+			hdr.Name += "/"
+			hdr.SetMode(archiveMode(info) | fs.ModeDir)
+
+			_, err := zw.CreateHeader(hdr)
+
+			return err
+		}
+
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+
+		return copyFile(fw, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// copyFile is a helper function to copy the contents of the file at path to w.
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+
+	return err
+}
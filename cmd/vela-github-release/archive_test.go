@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGithubRelease_archiveName(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr error
+	}{
+		{name: "default", text: "", want: "dist.tar.gz"},
+		{name: "tag", text: "{{ .Name }}-{{ .Tag }}.{{ .Format }}", want: "dist-v1.0.0.tar.gz"},
+		{name: "missing key", text: "{{ .Missing }}", wantErr: ErrorInvalidTemplate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := archiveName(test.text, "build/dist", archiveTarGz, "v1.0.0")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("archiveName error = %v, wantErr = %v", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("archiveName is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_validateArchive(t *testing.T) {
+	for _, format := range []string{"", archiveTarGz, archiveZip} {
+		err := validateArchive(format)
+		if err != nil {
+			t.Errorf("validateArchive(%q) returned err: %v", format, err)
+		}
+	}
+
+	err := validateArchive("rar")
+	if !errors.Is(err, ErrorInvalidArchiveFormat) {
+		t.Errorf("validateArchive error = %v, wantErr = %v", err, ErrorInvalidArchiveFormat)
+	}
+}
+
+// setupArchiveDir is a helper function to create a directory
+// for archiving with the provided modification time.
+func setupArchiveDir(t *testing.T, mtime time.Time) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "dist")
+
+	files := map[string]os.FileMode{
+		"bin/app":   0700,
+		"README.md": 0600,
+		"z.txt":     0640,
+	}
+
+	for file, mode := range files {
+		path := filepath.Join(dir, file)
+
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			t.Fatalf("Unable to create directory: %v", err)
+		}
+
+		err = os.WriteFile(path, []byte(file), mode)
+		if err != nil {
+			t.Fatalf("Unable to write file %s: %v", path, err)
+		}
+
+		err = os.Chtimes(path, mtime, mtime)
+		if err != nil {
+			t.Fatalf("Unable to change times of %s: %v", path, err)
+		}
+	}
+
+	return dir
+}
+
+func TestGithubRelease_archiveDir_TarGz(t *testing.T) {
+	var archives [][]byte
+
+	// archive the same content with different modification times
+	for _, mtime := range []time.Time{time.Now(), time.Now().Add(-time.Hour)} {
+		dst := filepath.Join(t.TempDir(), "dist.tar.gz")
+
+		err := archiveDir(setupArchiveDir(t, mtime), dst, archiveTarGz)
+		if err != nil {
+			t.Fatalf("archiveDir returned err: %v", err)
+		}
+
+		b, err := os.ReadFile(dst)
+		if err != nil {
+			t.Fatalf("Unable to read archive: %v", err)
+		}
+
+		archives = append(archives, b)
+	}
+
+	if !bytes.Equal(archives[0], archives[1]) {
+		t.Errorf("archiveDir should create reproducible archives")
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(archives[0]))
+	if err != nil {
+		t.Fatalf("Unable to read gzip: %v", err)
+	}
+
+	want := []struct {
+		name string
+		mode int64
+	}{
+		{"README.md", 0644},
+		{"bin/", 0755},
+		{"bin/app", 0755},
+		{"z.txt", 0644},
+	}
+
+	tr := tar.NewReader(gr)
+
+	for _, w := range want {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatalf("Unable to read tar entry: %v", err)
+		}
+
+		if hdr.Name != w.name || hdr.Mode != w.mode || !hdr.ModTime.Equal(_archiveTime) {
+			t.Errorf("tar entry is (%v, %o, %v), want (%v, %o, %v)", hdr.Name, hdr.Mode, hdr.ModTime, w.name, w.mode, _archiveTime)
+		}
+	}
+
+	if _, err := tr.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("tar archive should only contain %d entries", len(want))
+	}
+}
+
+func TestGithubRelease_archiveDir_Zip(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "dist.zip")
+
+	err := archiveDir(setupArchiveDir(t, time.Now()), dst, archiveZip)
+	if err != nil {
+		t.Fatalf("archiveDir returned err: %v", err)
+	}
+
+	zr, err := zip.OpenReader(dst)
+	if err != nil {
+		t.Fatalf("Unable to read zip: %v", err)
+	}
+	defer zr.Close()
+
+	want := []string{"README.md", "bin/", "bin/app", "z.txt"}
+
+	if len(zr.File) != len(want) {
+		t.Fatalf("zip archive has %d entries, want %d", len(zr.File), len(want))
+	}
+
+	for i, f := range zr.File {
+		if f.Name != want[i] || !f.Modified.Equal(_archiveTime) {
+			t.Errorf("zip entry is (%v, %v), want (%v, %v)", f.Name, f.Modified, want[i], _archiveTime)
+		}
+	}
+
+	if zr.File[2].Mode().Perm() != 0755 {
+		t.Errorf("zip entry %s mode is %o, want %o", zr.File[2].Name, zr.File[2].Mode().Perm(), 0755)
+	}
+}
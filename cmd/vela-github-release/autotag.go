@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// autoTag is the tag value requesting the next version be computed.
+	autoTag = "auto"
+
+	// _releaseLimit is the maximum number of releases to inspect.
+	_releaseLimit = 1000
+)
+
+// bump is a helper function to increment the provided version
+// based off the Conventional Commit types of the commits.
+func bump(v *semver.Version, commits []commit) *semver.Version {
+	var minor bool
+
+	for _, c := range commits {
+		// a breaking change always results in a major version
+		if c.Breaking {
+			next := v.IncMajor()
+
+			return &next
+		}
+
+		if c.Type == "feat" {
+			minor = true
+		}
+	}
+
+	if minor {
+		next := v.IncMinor()
+
+		return &next
+	}
+
+	next := v.IncPatch()
+
+	return &next
+}
+
+// nextVersion is a helper function to compute the next tag from the
+// existing releases and the commits since the latest stable release.
+func nextVersion(releases []release, commits []commit, prereleaseID string) string {
+	latest, base := latestStable(releases)
+
+	// start from the initial version when there are no stable releases
+	prefix := "v"
+	if base != nil {
+		prefix = tagPrefix(latest.TagName)
+	} else {
+		base = semver.New(0, 0, 0, "", "")
+	}
+
+	next := bump(base, commits)
+
+	// check if a prerelease identifier was provided
+	if len(prereleaseID) == 0 {
+		return prefix + next.String()
+	}
+
+	// continue an existing prerelease series for a higher version
+	for _, r := range releases {
+		v := r.Version()
+		if v == nil || !strings.HasPrefix(v.Prerelease(), prereleaseID+".") {
+			continue
+		}
+
+		core := semver.New(v.Major(), v.Minor(), v.Patch(), "", "")
+		if core.GreaterThan(next) {
+			next = core
+		}
+	}
+
+	var number uint64
+
+	// find the highest prerelease number for the next version
+	for _, r := range releases {
+		v := r.Version()
+		if v == nil || v.Major() != next.Major() || v.Minor() != next.Minor() || v.Patch() != next.Patch() {
+			continue
+		}
+
+		var n uint64
+
+		_, err := fmt.Sscanf(v.Prerelease(), prereleaseID+".%d", &n)
+		if err == nil && n > number {
+			number = n
+		}
+	}
+
+	return fmt.Sprintf("%s%s-%s.%d", prefix, next.String(), prereleaseID, number+1)
+}
+
+// nextTag computes the next tag from the latest release in the
+// repository and the commits in the workspace since that release.
+func nextTag(ctx context.Context, prereleaseID string) (string, error) {
+	logrus.Debug("computing next tag from releases and commits")
+
+	releases, err := listReleases(ctx, remote{}, _releaseLimit)
+	if err != nil {
+		return "", err
+	}
+
+	// capture the commits since the latest stable release
+	rng := "HEAD"
+	if latest, base := latestStable(releases); base != nil {
+		rng = fmt.Sprintf("%s..HEAD", latest.TagName)
+	}
+
+	commits, err := gitCommits(ctx, rng)
+	if err != nil {
+		return "", err
+	}
+
+	tag := nextVersion(releases, commits, prereleaseID)
+
+	logrus.Infof("computed next tag %s from %d commits in %s", tag, len(commits), rng)
+
+	// expose the computed tag to later steps
+	err = writeOutputs(map[string]string{"GITHUB_RELEASE_TAG": tag})
+	if err != nil {
+		return "", err
+	}
+
+	return tag, nil
+}
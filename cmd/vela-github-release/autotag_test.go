@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+)
+
+func TestGithubRelease_nextVersion(t *testing.T) {
+	// setup types
+	releases := []release{
+		{TagName: "v1.2.0"},
+		{TagName: "v1.1.0"},
+		{TagName: "v1.3.0-rc.1", IsPrerelease: true},
+		{TagName: "v1.3.0-rc.2", IsPrerelease: true},
+		{TagName: "v9.9.9", IsDraft: true},
+		{TagName: "nightly"},
+	}
+
+	tests := []struct {
+		name         string
+		releases     []release
+		commits      []commit
+		prereleaseID string
+		want         string
+	}{
+		{
+			name:     "patch",
+			releases: releases,
+			commits:  []commit{{Type: "fix"}, {Type: "chore"}},
+			want:     "v1.2.1",
+		},
+		{
+			name:     "minor",
+			releases: releases,
+			commits:  []commit{{Type: "fix"}, {Type: "feat"}},
+			want:     "v1.3.0",
+		},
+		{
+			name:     "major",
+			releases: releases,
+			commits:  []commit{{Type: "feat"}, {Type: "fix", Breaking: true}},
+			want:     "v2.0.0",
+		},
+		{
+			name:         "continue prerelease series",
+			releases:     releases,
+			commits:      []commit{{Type: "fix"}},
+			prereleaseID: "rc",
+			want:         "v1.3.0-rc.3",
+		},
+		{
+			name:         "new prerelease series",
+			releases:     releases,
+			commits:      []commit{{Type: "feat", Breaking: true}},
+			prereleaseID: "rc",
+			want:         "v2.0.0-rc.1",
+		},
+		{
+			name:    "no releases",
+			commits: []commit{{Type: "feat"}},
+			want:    "v0.1.0",
+		},
+		{
+			name:     "unprefixed",
+			releases: []release{{TagName: "0.4.2"}},
+			commits:  []commit{{Type: "fix"}},
+			want:     "0.4.3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := nextVersion(test.releases, test.commits, test.prereleaseID)
+
+			if got != test.want {
+				t.Errorf("nextVersion is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_nextTag_Error(t *testing.T) {
+	_, err := nextTag(t.Context(), "")
+	if err == nil {
+		t.Errorf("nextTag should have returned err")
+	}
+}
@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+const (
+	// _cacheBinary is the name of the gh binary stored in a cache entry.
+	_cacheBinary = "gh"
+	// _cacheChecksum is the name of the file storing the gh binary digest in a cache entry.
+	_cacheChecksum = "gh.sha256"
+	// _cacheKey is the template for the name of a cache entry.
+	_cacheKey = "{version}_{os}_{arch}"
+)
+
+// digest is a helper function to return the
+// hex encoded sha256 digest of the provided bytes.
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheEntry is a helper function to return the path to the
+// cache entry for the requested gh version, OS and arch.
+func (g *GH) cacheEntry() string {
+	return filepath.Join(g.CacheDir, g.render(_cacheKey))
+}
+
+// fromCache attempts to install the gh binary from the cache.
+// It returns false when no verified binary is cached.
+func (g *GH) fromCache() (bool, error) {
+	// use custom filesystem which enables us to test
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	entry := g.cacheEntry()
+
+	// read the cached gh binary
+	bin, err := a.ReadFile(filepath.Join(entry, _cacheBinary))
+	if err != nil {
+		logrus.Debugf("no cached gh binary found in %s", entry)
+
+		return false, nil
+	}
+
+	// read the digest stored alongside the gh binary
+	sum, err := a.ReadFile(filepath.Join(entry, _cacheChecksum))
+	if err != nil || !strings.EqualFold(strings.TrimSpace(string(sum)), digest(bin)) {
+		logrus.Warnf("cached gh binary in %s failed verification, removing it", entry)
+
+		return false, a.RemoveAll(entry)
+	}
+
+	logrus.Infof("installing gh version from cache: %s", entry)
+
+	err = a.WriteFile(_gh, bin, 0700)
+	if err != nil {
+		return false, err
+	}
+
+	// mark the entry as recently used so it survives eviction
+	now := time.Now()
+
+	return true, a.Chtimes(entry, now, now)
+}
+
+// toCache stores the installed gh binary in the cache
+// and evicts the oldest entries beyond the cache limit.
+func (g *GH) toCache() error {
+	// use custom filesystem which enables us to test
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	entry := g.cacheEntry()
+
+	logrus.Debugf("storing gh binary in cache: %s", entry)
+
+	bin, err := a.ReadFile(_gh)
+	if err != nil {
+		return err
+	}
+
+	err = a.MkdirAll(entry, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = a.WriteFile(filepath.Join(entry, _cacheBinary), bin, 0700)
+	if err != nil {
+		return err
+	}
+
+	err = a.WriteFile(filepath.Join(entry, _cacheChecksum), []byte(digest(bin)), 0600)
+	if err != nil {
+		return err
+	}
+
+	return g.evict()
+}
+
+// isCacheEntry is a helper function to check if the provided
+// directory is a gh cache entry named after the cache key
+// and holding the digest of its gh binary.
+func isCacheEntry(a *afero.Afero, dir string) bool {
+	parts := strings.Split(filepath.Base(dir), "_")
+	if len(parts) != 3 || len(parts[1]) == 0 || len(parts[2]) == 0 {
+		return false
+	}
+
+	_, err := semver.StrictNewVersion(parts[0])
+	if err != nil {
+		return false
+	}
+
+	ok, _ := a.Exists(filepath.Join(dir, _cacheChecksum))
+
+	return ok
+}
+
+// evict removes the least recently used cache entries until no
+// more than the cache limit remain and they fit in the cache size.
+// The most recently used entry is always kept, and directories
+// that are not cache entries are never removed.
+func (g *GH) evict() error {
+	// use custom filesystem which enables us to test
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// check if the cache is unbounded
+	if g.CacheLimit <= 0 && g.CacheSize <= 0 {
+		return nil
+	}
+
+	entries, err := a.ReadDir(g.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	// sort the entries from most to least recently used
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ModTime().After(entries[j].ModTime())
+	})
+
+	var (
+		kept int
+		size int64
+	)
+
+	for _, entry := range entries {
+		path := filepath.Join(g.CacheDir, entry.Name())
+
+		if !entry.IsDir() || !isCacheEntry(a, path) {
+			continue
+		}
+
+		entrySize, err := dirSize(a, path)
+		if err != nil {
+			return err
+		}
+
+		kept++
+		size += entrySize
+
+		// check if the entry fits in the cache limit and size
+		if kept == 1 ||
+			((g.CacheLimit <= 0 || kept <= g.CacheLimit) &&
+				(g.CacheSize <= 0 || size <= int64(g.CacheSize)<<20)) {
+			continue
+		}
+
+		logrus.Infof("evicting gh version %s from cache", entry.Name())
+
+		err = a.RemoveAll(path)
+		if err != nil {
+			return err
+		}
+
+		kept--
+		size -= entrySize
+	}
+
+	return nil
+}
+
+// dirSize is a helper function to return the
+// total size in bytes of the files in a directory.
+func dirSize(a *afero.Afero, dir string) (int64, error) {
+	var size int64
+
+	err := a.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func TestGithub_CLI_fromCache(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup types
+	g := &GH{
+		CacheDir: "/vela/cache/gh",
+		Version:  "v2.40.0",
+	}
+
+	bin := []byte("gh binary")
+
+	// seed the cache
+	err := a.WriteFile(filepath.Join(g.cacheEntry(), _cacheBinary), bin, 0700)
+	if err != nil {
+		t.Errorf("Unable to write cached binary: %v", err)
+	}
+
+	err = a.WriteFile(filepath.Join(g.cacheEntry(), _cacheChecksum), []byte(digest(bin)), 0600)
+	if err != nil {
+		t.Errorf("Unable to write cached checksum: %v", err)
+	}
+
+	// run test
+	ok, err := g.fromCache()
+	if err != nil {
+		t.Errorf("fromCache returned err: %v", err)
+	}
+
+	if !ok {
+		t.Errorf("fromCache should have installed the cached binary")
+	}
+
+	got, err := a.ReadFile(_gh)
+	if err != nil {
+		t.Errorf("Unable to read installed binary: %v", err)
+	}
+
+	if string(got) != string(bin) {
+		t.Errorf("installed binary is %s, want %s", got, bin)
+	}
+}
+
+func TestGithub_CLI_fromCache_Corrupt(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup types
+	g := &GH{
+		CacheDir: "/vela/cache/gh",
+		Version:  "2.40.0",
+	}
+
+	// seed the cache with a mismatched checksum
+	err := a.WriteFile(filepath.Join(g.cacheEntry(), _cacheBinary), []byte("tampered"), 0700)
+	if err != nil {
+		t.Errorf("Unable to write cached binary: %v", err)
+	}
+
+	err = a.WriteFile(filepath.Join(g.cacheEntry(), _cacheChecksum), []byte(digest([]byte("gh binary"))), 0600)
+	if err != nil {
+		t.Errorf("Unable to write cached checksum: %v", err)
+	}
+
+	// run test
+	ok, err := g.fromCache()
+	if err != nil {
+		t.Errorf("fromCache returned err: %v", err)
+	}
+
+	if ok {
+		t.Errorf("fromCache should not have installed a corrupt binary")
+	}
+
+	exists, _ := a.DirExists(g.cacheEntry())
+	if exists {
+		t.Errorf("fromCache should have removed the corrupt entry")
+	}
+}
+
+func TestGithub_CLI_toCache(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup types
+	g := &GH{
+		CacheDir:   "/vela/cache/gh",
+		CacheLimit: 2,
+		Version:    "2.40.0",
+	}
+
+	// seed the cache with old versions and an unrelated directory
+	for i, name := range []string{"other", cacheKey("2.37.0"), cacheKey("2.38.0"), cacheKey("2.39.0")} {
+		dir := filepath.Join(g.CacheDir, name)
+
+		err := a.WriteFile(filepath.Join(dir, _cacheChecksum), []byte("checksum"), 0600)
+		if err != nil {
+			t.Errorf("Unable to create cache entry %s: %v", dir, err)
+		}
+
+		mtime := time.Now().Add(time.Duration(i-10) * time.Hour)
+
+		err = a.Chtimes(dir, mtime, mtime)
+		if err != nil {
+			t.Errorf("Unable to set times for %s: %v", dir, err)
+		}
+	}
+
+	// create installed binary
+	err := a.WriteFile(_gh, []byte("gh binary"), 0700)
+	if err != nil {
+		t.Errorf("Unable to write file %s: %v", _gh, err)
+	}
+
+	// run test
+	err = g.toCache()
+	if err != nil {
+		t.Errorf("toCache returned err: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"other":            true,
+		cacheKey("2.37.0"): false,
+		cacheKey("2.38.0"): false,
+		cacheKey("2.39.0"): true,
+		cacheKey("2.40.0"): true,
+	} {
+		got, _ := a.DirExists(filepath.Join(g.CacheDir, name))
+		if got != want {
+			t.Errorf("cache entry %s exists is %v, want %v", name, got, want)
+		}
+	}
+
+	sum, err := a.ReadFile(filepath.Join(g.cacheEntry(), _cacheChecksum))
+	if err != nil {
+		t.Errorf("Unable to read cached checksum: %v", err)
+	}
+
+	if want := digest([]byte("gh binary")); string(sum) != want {
+		t.Errorf("cached checksum is %s, want %s", sum, want)
+	}
+}
+
+func TestGithub_CLI_evict_Size(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup types
+	g := &GH{
+		CacheDir:  "/vela/cache/gh",
+		CacheSize: 1,
+	}
+
+	// seed the cache with an unrelated directory and versions of 400 KiB each
+	for i, name := range []string{"other", cacheKey("2.37.0"), cacheKey("2.38.0"), cacheKey("2.39.0"), cacheKey("2.40.0")} {
+		dir := filepath.Join(g.CacheDir, name)
+
+		for file, size := range map[string]int{_cacheBinary: 400 << 10, _cacheChecksum: 64} {
+			err := a.WriteFile(filepath.Join(dir, file), make([]byte, size), 0600)
+			if err != nil {
+				t.Errorf("Unable to write cache entry %s: %v", dir, err)
+			}
+		}
+
+		mtime := time.Now().Add(time.Duration(i-10) * time.Hour)
+
+		err := a.Chtimes(dir, mtime, mtime)
+		if err != nil {
+			t.Errorf("Unable to set times for %s: %v", dir, err)
+		}
+	}
+
+	// run test
+	err := g.evict()
+	if err != nil {
+		t.Errorf("evict returned err: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"other":            true,
+		cacheKey("2.37.0"): false,
+		cacheKey("2.38.0"): false,
+		cacheKey("2.39.0"): true,
+		cacheKey("2.40.0"): true,
+	} {
+		got, _ := a.DirExists(filepath.Join(g.CacheDir, name))
+		if got != want {
+			t.Errorf("cache entry %s exists is %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestGithub_CLI_Exec_Cache(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup types
+	g := &GH{
+		CacheDir: "/vela/cache/gh",
+		Default:  "2.14.4",
+		Tarball:  "testdata/missing.tar.gz",
+		Version:  "2.40.0",
+	}
+
+	bin := []byte("gh binary")
+
+	// create default binary
+	err := a.WriteFile(_gh, []byte("default"), 0700)
+	if err != nil {
+		t.Errorf("Unable to write file %s: %v", _gh, err)
+	}
+
+	// seed the cache
+	for name, content := range map[string][]byte{
+		_cacheBinary:   bin,
+		_cacheChecksum: []byte(digest(bin)),
+	} {
+		err = a.WriteFile(filepath.Join(g.cacheEntry(), name), content, 0600)
+		if err != nil {
+			t.Errorf("Unable to write %s: %v", name, err)
+		}
+	}
+
+	// run test
+	err = g.Exec(t.Context())
+	if err != nil {
+		t.Errorf("Exec returned err: %v", err)
+	}
+
+	got, _ := a.ReadFile(_gh)
+	if string(got) != string(bin) {
+		t.Errorf("installed binary is %s, want %s", got, bin)
+	}
+
+	def, _ := a.ReadFile(fmt.Sprintf("%s.default", _gh))
+	if string(def) != "default" {
+		t.Errorf("default binary is %s, want default", def)
+	}
+}
+
+// cacheKey is a helper function to return the name of the
+// cache entry for a gh version on the current OS and arch.
+func cacheKey(version string) string {
+	return fmt.Sprintf("%s_%s_%s", version, runtime.GOOS, runtime.GOARCH)
+}
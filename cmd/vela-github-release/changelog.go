@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	_git = "git"
+
+	// _logFormat separates fields with a unit separator and commits with a record separator.
+	_logFormat = "--format=%H%x1f%s%x1f%b%x1e"
+)
+
+var (
+	// conventionalRegex matches a Conventional Commit subject: type(scope)!: description.
+	conventionalRegex = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+	// pullRequestRegex matches a pull request reference at the end of a commit subject.
+	pullRequestRegex = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+
+	// changelogSections defines the order and headings of the changelog sections.
+	changelogSections = []struct {
+		Type    string
+		Heading string
+	}{
+		{"breaking", "Breaking Changes"},
+		{"feat", "Features"},
+		{"fix", "Bug Fixes"},
+		{"perf", "Performance Improvements"},
+		{"other", "Other Changes"},
+	}
+)
+
+// commit represents a commit parsed from the git history.
+type commit struct {
+	// commit is a breaking change
+	Breaking bool
+	// description of the change
+	Description string
+	// pull request number referenced by the commit
+	PullRequest string
+	// scope of the change
+	Scope string
+	// full SHA of the commit
+	SHA string
+	// Conventional Commit type of the change
+	Type string
+}
+
+// parseCommit is a helper function to parse a commit
+// from its SHA, subject and body.
+func parseCommit(sha, subject, body string) commit {
+	c := commit{
+		Description: subject,
+		SHA:         sha,
+		Type:        "other",
+	}
+
+	// capture the pull request reference from the subject
+	if m := pullRequestRegex.FindStringSubmatch(c.Description); m != nil {
+		c.PullRequest = m[1]
+		c.Description = strings.TrimSuffix(c.Description, m[0])
+	}
+
+	// check if the subject follows the Conventional Commit format
+	if m := conventionalRegex.FindStringSubmatch(c.Description); m != nil {
+		c.Type = strings.ToLower(m[1])
+		c.Scope = m[2]
+		c.Breaking = len(m[3]) > 0
+		c.Description = m[4]
+	}
+
+	// check if the body declares a breaking change
+	if strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:") {
+		c.Breaking = true
+	}
+
+	return c
+}
+
+// section returns the changelog section the commit belongs to.
+func (c commit) section() string {
+	if c.Breaking {
+		return "breaking"
+	}
+
+	switch c.Type {
+	case "feat", "fix", "perf":
+		return c.Type
+	default:
+		return "other"
+	}
+}
+
+// renderChangelog is a helper function to render the provided commits
+// as markdown, linking SHAs and pull requests when a repo link is provided.
+func renderChangelog(commits []commit, link string) string {
+	var b strings.Builder
+
+	for _, section := range changelogSections {
+		var lines []string
+
+		for _, c := range commits {
+			if c.section() != section.Type {
+				continue
+			}
+
+			line := "* "
+
+			if len(c.Scope) > 0 {
+				line += fmt.Sprintf("**%s:** ", c.Scope)
+			}
+
+			line += c.Description
+
+			short := c.SHA
+			if len(short) > 7 {
+				short = short[:7]
+			}
+
+			// link the commit and pull request when the repo link is known
+			if len(link) > 0 {
+				line += fmt.Sprintf(" ([%s](%s/commit/%s))", short, link, c.SHA)
+
+				if len(c.PullRequest) > 0 {
+					line += fmt.Sprintf(" ([#%s](%s/pull/%s))", c.PullRequest, link, c.PullRequest)
+				}
+			} else {
+				line += fmt.Sprintf(" (%s)", short)
+
+				if len(c.PullRequest) > 0 {
+					line += fmt.Sprintf(" (#%s)", c.PullRequest)
+				}
+			}
+
+			lines = append(lines, line)
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n%s\n", section.Heading, strings.Join(lines, "\n"))
+	}
+
+	return b.String()
+}
+
+// gitRef is a helper function to check if the
+// provided ref exists in the local repository.
+func gitRef(ctx context.Context, ref string) bool {
+	cmd := exec.CommandContext(ctx, _git, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+
+	_, err := outputCmd(cmd)
+
+	return err == nil
+}
+
+// gitCommits is a helper function to parse the commits
+// in the provided range from the local git history.
+func gitCommits(ctx context.Context, rng string) ([]commit, error) {
+	out, err := outputCmd(exec.CommandContext(ctx, _git, "log", "--no-merges", _logFormat, rng))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read git history for %s: %w", rng, err)
+	}
+
+	var commits []commit
+
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) < 2 {
+			continue
+		}
+
+		body := ""
+		if len(fields) > 2 {
+			body = fields[2]
+		}
+
+		commits = append(commits, parseCommit(fields[0], fields[1], body))
+	}
+
+	return commits, nil
+}
+
+// changelog creates a markdown changelog from the local git
+// history between the previous tag and the provided tag.
+func changelog(ctx context.Context, from, tag, link string) (string, error) {
+	logrus.Debug("creating changelog from git history")
+
+	// use HEAD when the tag has not been created yet
+	to := tag
+	if !gitRef(ctx, to) {
+		to = "HEAD"
+	}
+
+	// check if the previous tag was provided
+	if len(from) == 0 {
+		out, err := outputCmd(exec.CommandContext(ctx, _git, "describe", "--tags", "--abbrev=0", to+"^"))
+		if err != nil {
+			logrus.Infof("no previous tag found for %s, using the full history", to)
+		}
+
+		from = strings.TrimSpace(string(out))
+	}
+
+	rng := to
+	if len(from) > 0 {
+		rng = fmt.Sprintf("%s..%s", from, to)
+	}
+
+	logrus.Infof("creating changelog for commits in %s", rng)
+
+	commits, err := gitCommits(ctx, rng)
+	if err != nil {
+		return "", err
+	}
+
+	return renderChangelog(commits, strings.TrimSuffix(link, "/")), nil
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestGithubRelease_parseCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    commit
+	}{
+		{
+			name:    "feature",
+			subject: "feat(create): add changelog (#42)",
+			want:    commit{Type: "feat", Scope: "create", Description: "add changelog", PullRequest: "42"},
+		},
+		{
+			name:    "breaking subject",
+			subject: "fix!: drop legacy flags",
+			want:    commit{Type: "fix", Description: "drop legacy flags", Breaking: true},
+		},
+		{
+			name:    "breaking body",
+			subject: "perf: faster uploads",
+			body:    "BREAKING CHANGE: requires gh 2.40",
+			want:    commit{Type: "perf", Description: "faster uploads", Breaking: true},
+		},
+		{
+			name:    "not conventional",
+			subject: "Update README.md",
+			want:    commit{Type: "other", Description: "Update README.md"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.want.SHA = "abc"
+
+			got := parseCommit("abc", test.subject, test.body)
+
+			if got != test.want {
+				t.Errorf("parseCommit is %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_renderChangelog(t *testing.T) {
+	// setup types
+	commits := []commit{
+		{SHA: "1111111111", Type: "fix", Description: "handle empty tag", PullRequest: "7"},
+		{SHA: "2222222222", Type: "feat", Scope: "upload", Description: "add labels"},
+		{SHA: "3333333333", Type: "feat", Description: "new api", Breaking: true},
+		{SHA: "4444444444", Type: "chore", Description: "bump deps"},
+	}
+
+	want := `## Breaking Changes
+
+* new api ([3333333](https://github.com/go-vela/vela-github-release/commit/3333333333))
+
+## Features
+
+* **upload:** add labels ([2222222](https://github.com/go-vela/vela-github-release/commit/2222222222))
+
+## Bug Fixes
+
+* handle empty tag ([1111111](https://github.com/go-vela/vela-github-release/commit/1111111111)) ([#7](https://github.com/go-vela/vela-github-release/pull/7))
+
+## Other Changes
+
+* bump deps ([4444444](https://github.com/go-vela/vela-github-release/commit/4444444444))
+`
+
+	got := renderChangelog(commits, "https://github.com/go-vela/vela-github-release")
+
+	if got != want {
+		t.Errorf("renderChangelog is %v, want %v", got, want)
+	}
+}
+
+func TestGithubRelease_changelog(t *testing.T) {
+	if _, err := exec.LookPath(_git); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// setup repository
+	t.Chdir(t.TempDir())
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"-c", "user.name=vela", "-c", "user.email=vela@example.com", "commit", "--quiet", "--allow-empty", "-m", "feat: initial"},
+		{"tag", "v0.1.0"},
+		{"-c", "user.name=vela", "-c", "user.email=vela@example.com", "commit", "--quiet", "--allow-empty", "-m", "fix: handle empty tag (#7)"},
+		{"-c", "user.name=vela", "-c", "user.email=vela@example.com", "commit", "--quiet", "--allow-empty", "-m", "feat(upload): add labels"},
+	} {
+		out, err := exec.CommandContext(t.Context(), _git, args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v returned err: %v: %s", args, err, out)
+		}
+	}
+
+	got, err := changelog(t.Context(), "", "v0.2.0", "")
+	if err != nil {
+		t.Errorf("changelog returned err: %v", err)
+	}
+
+	if !strings.Contains(got, "## Features\n\n* **upload:** add labels") {
+		t.Errorf("changelog is missing feature: %v", got)
+	}
+
+	if !strings.Contains(got, "## Bug Fixes\n\n* handle empty tag") || !strings.Contains(got, "(#7)") {
+		t.Errorf("changelog is missing fix: %v", got)
+	}
+
+	if strings.Contains(got, "initial") {
+		t.Errorf("changelog should not contain commits before v0.1.0: %v", got)
+	}
+}
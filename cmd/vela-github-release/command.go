@@ -34,6 +34,62 @@ func execCmd(e *exec.Cmd, f *os.File) error {
 	return e.Run()
 }
 
+// outputCmd is a helper function to run the
+// provided command and capture its output.
+func outputCmd(e *exec.Cmd) ([]byte, error) {
+	logrus.Tracef("executing cmd %s", strings.Join(e.Args, " "))
+
+	// set command stderr to OS stderr
+	e.Stderr = os.Stderr
+
+	return e.Output()
+}
+
+// withRepo is a helper function to run the provided gh command against
+// the repo in the [HOST/]OWNER/REPO format instead of the current repository.
+func withRepo(e *exec.Cmd, repo string) *exec.Cmd {
+	if len(repo) == 0 {
+		return e
+	}
+
+	e.Env = append(os.Environ(), fmt.Sprintf("GH_REPO=%s", repo))
+
+	return e
+}
+
+// withToken is a helper function to authenticate the provided gh command
+// with the token instead of the gh login or a token in the environment.
+func withToken(e *exec.Cmd, token string) *exec.Cmd {
+	if len(token) == 0 {
+		return e
+	}
+
+	if e.Env == nil {
+		e.Env = os.Environ()
+	}
+
+	// the command only targets a single host, so both the github.com
+	// and enterprise variables are set to cover the host of the command
+	e.Env = append(e.Env, fmt.Sprintf("GH_TOKEN=%s", token), fmt.Sprintf("GH_ENTERPRISE_TOKEN=%s", token))
+
+	return e
+}
+
+// remote represents a repository and the token to authenticate to
+// its host, for actions that span more than one repository or host.
+type remote struct {
+	// repository in the [HOST/]OWNER/REPO format (default: current repository)
+	Repo string
+	// token to authenticate to the host of the repository (default: gh login)
+	Token string
+}
+
+// withRemote is a helper function to run the provided gh command
+// against the repository of the remote with the token of the remote.
+func withRemote(e *exec.Cmd, r remote) *exec.Cmd {
+	return withToken(withRepo(e, r.Repo), r.Token)
+}
+
 // versionCmd is a helper function to output
 // the gh version information.
 func versionCmd(ctx context.Context) *exec.Cmd {
@@ -41,3 +41,69 @@ func TestGithubRelease_versionCmd(t *testing.T) {
 		}
 	}
 }
+
+func TestGithubRelease_outputCmd(t *testing.T) {
+	// setup types
+	e := exec.CommandContext(t.Context(), "echo", "hello")
+
+	got, err := outputCmd(e)
+	if err != nil {
+		t.Errorf("outputCmd returned err: %v", err)
+	}
+
+	if string(got) != "hello\n" {
+		t.Errorf("outputCmd is %q, want %q", got, "hello\n")
+	}
+}
+
+func TestGithubRelease_withRepo(t *testing.T) {
+	// setup types
+	e := withRepo(exec.CommandContext(t.Context(), "sh", "-c", "echo $GH_REPO"), "github.example.com/octocat/hello")
+
+	got, err := outputCmd(e)
+	if err != nil {
+		t.Errorf("outputCmd returned err: %v", err)
+	}
+
+	if string(got) != "github.example.com/octocat/hello\n" {
+		t.Errorf("GH_REPO is %q, want %q", got, "github.example.com/octocat/hello\n")
+	}
+
+	if withRepo(exec.CommandContext(t.Context(), "true"), "").Env != nil {
+		t.Errorf("withRepo should not change the environment without a repo")
+	}
+}
+
+func TestGithubRelease_withToken(t *testing.T) {
+	// setup types
+	e := withToken(exec.CommandContext(t.Context(), "sh", "-c", "echo $GH_TOKEN $GH_ENTERPRISE_TOKEN"), "token")
+
+	got, err := outputCmd(e)
+	if err != nil {
+		t.Errorf("outputCmd returned err: %v", err)
+	}
+
+	if string(got) != "token token\n" {
+		t.Errorf("tokens are %q, want %q", got, "token token\n")
+	}
+
+	if withToken(exec.CommandContext(t.Context(), "true"), "").Env != nil {
+		t.Errorf("withToken should not change the environment without a token")
+	}
+}
+
+func TestGithubRelease_withRemote(t *testing.T) {
+	// setup types
+	r := remote{Repo: "github.example.com/octocat/hello", Token: "token"}
+
+	e := withRemote(exec.CommandContext(t.Context(), "sh", "-c", "echo $GH_REPO $GH_TOKEN $GH_ENTERPRISE_TOKEN"), r)
+
+	got, err := outputCmd(e)
+	if err != nil {
+		t.Errorf("outputCmd returned err: %v", err)
+	}
+
+	if want := "github.example.com/octocat/hello token token\n"; string(got) != want {
+		t.Errorf("environment is %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const copyAction = "copy"
+
+var (
+	// ErrorNoCopySourceRepo is returned when the plugin is missing the copy source repository.
+	ErrorNoCopySourceRepo = errors.New("no copy source repo provided")
+
+	// ErrorNoCopyTag is returned when the plugin is missing the copy tag.
+	ErrorNoCopyTag = errors.New("no copy tag provided")
+)
+
+// Copy represents the plugin configuration for Copy config information.
+type Copy struct {
+	// tag name of the release in the destination repository (default: tag)
+	DestinationTag string
+	// list of patterns for assets to skip
+	Exclude []string
+	// policy the destination tag must satisfy
+	Policy *TagPolicy
+	// destination repository in the [HOST/]OWNER/REPO format (default: current repository)
+	Repo string
+	// hostname of the source repository (default: gh default host)
+	SourceHostname string
+	// source repository in the OWNER/REPO format
+	SourceRepo string
+	// token to authenticate to the source hostname (default: gh login)
+	SourceToken string
+	// tag name of the release to copy
+	Tag string
+	// target branch or commit SHA for the destination tag (default: default branch)
+	Target string
+}
+
+// source returns the source repository in the [HOST/]OWNER/REPO
+// format along with the token to authenticate to its host.
+func (c *Copy) source() remote {
+	return remote{
+		Repo:  repoPath(c.SourceHostname, c.SourceRepo),
+		Token: c.SourceToken,
+	}
+}
+
+// destination returns the tag name of the release in the destination repository.
+func (c *Copy) destination() string {
+	if len(c.DestinationTag) > 0 {
+		return c.DestinationTag
+	}
+
+	return c.Tag
+}
+
+// Exec formats and runs the commands for applying
+// the provided configuration to the resources.
+func (c *Copy) Exec(ctx context.Context) error {
+	logrus.Debug("running copy with provided configuration")
+
+	tag := c.destination()
+
+	src, err := viewRelease(ctx, c.source(), c.Tag)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("copying release %s from %s as %s", c.Tag, c.source().Repo, tag)
+
+	_, err = copyRelease(ctx, src, c.source(), remote{Repo: c.Repo}, tag, c.Target, c.Exclude)
+
+	return err
+}
+
+// copyRelease is a helper function to recreate the source release with
+// its assets as the tag in the destination repository, skipping the
+// assets matching the exclude patterns, and returning the copied assets.
+func copyRelease(ctx context.Context, src release, from, to remote, tag, target string, exclude []string) ([]asset, error) {
+	// create a directory to pass the assets through
+	dir, err := os.MkdirTemp("", "vela-github-release-copy")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	assets, err := downloadAssets(ctx, src, from, dir, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	// run the create command for the destination repository
+	err = execCmd(createCommand(ctx, src, to, tag, target, assets), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// createCommand is a helper function to create the command to recreate
+// the source release with the assets as the tag in the destination repository.
+func createCommand(ctx context.Context, src release, to remote, tag, target string, assets []asset) *exec.Cmd {
+	create := &Create{
+		Draft:      src.IsDraft,
+		Notes:      src.Body,
+		Prerelease: strconv.FormatBool(src.IsPrerelease),
+		Tag:        tag,
+		Target:     target,
+		Title:      src.Name,
+		assets:     assets,
+	}
+
+	return withRemote(create.Command(ctx), to)
+}
+
+// downloadAssets is a helper function to download the assets of the
+// source release that don't match the exclude patterns into the directory,
+// returning the downloaded assets. The assets are returned as is, rather
+// than as files, so their names are not read as patterns or asset specs.
+func downloadAssets(ctx context.Context, src release, from remote, dir string, exclude []string) ([]asset, error) {
+	cmd, assets := downloadCommand(ctx, src, from, dir, exclude)
+
+	// check if there is anything to download
+	if len(assets) == 0 {
+		return nil, nil
+	}
+
+	// run the download command for the source repository
+	err := execCmd(cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// downloadCommand is a helper function to create the command to download
+// the assets of the source release that don't match the exclude patterns
+// into the directory, along with the assets it downloads.
+func downloadCommand(ctx context.Context, src release, from remote, dir string, exclude []string) (*exec.Cmd, []asset) {
+	d := &Download{
+		Directory: dir,
+		Tag:       src.TagName,
+	}
+
+	var assets []asset
+
+	for _, a := range src.Assets {
+		if excluded(a.Name, exclude) {
+			logrus.Infof("skipping asset %s", a.Name)
+
+			continue
+		}
+
+		d.Patterns = append(d.Patterns, globEscape(a.Name))
+		assets = append(assets, asset{Name: a.Name, Path: filepath.Join(dir, a.Name)})
+	}
+
+	return withRemote(d.Command(ctx), from), assets
+}
+
+// globEscape is a helper function to escape the glob
+// characters in the name so a pattern matches it literally.
+func globEscape(name string) string {
+	var b strings.Builder
+
+	for _, r := range name {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Validate verifies the Copy is properly configured.
+func (c *Copy) Validate() error {
+	logrus.Trace("validating copy configuration")
+
+	// verify copy source repo is provided
+	if len(c.SourceRepo) == 0 {
+		return ErrorNoCopySourceRepo
+	}
+
+	// verify copy tag is provided
+	if len(c.Tag) == 0 {
+		return ErrorNoCopyTag
+	}
+
+	// verify the destination tag satisfies the tag policy
+	return c.Policy.Validate(c.destination())
+}
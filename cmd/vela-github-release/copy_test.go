@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGithubRelease_Copy_source(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Copy
+		want remote
+	}{
+		{
+			name: "default host",
+			c:    &Copy{SourceRepo: "octocat/hello"},
+			want: remote{Repo: "octocat/hello"},
+		},
+		{
+			name: "hostname",
+			c:    &Copy{SourceHostname: "git.example.com", SourceRepo: "octocat/hello", SourceToken: "source"},
+			want: remote{Repo: "git.example.com/octocat/hello", Token: "source"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.source()
+
+			if got != test.want {
+				t.Errorf("source is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_downloadAssets_Excluded(t *testing.T) {
+	// setup types
+	src := release{
+		Assets: []releaseAsset{
+			{Name: "app.sig"},
+			{Name: "app.sbom.json"},
+		},
+		TagName: "v1.0.0",
+	}
+
+	got, err := downloadAssets(t.Context(), src, remote{Repo: "octocat/hello"}, t.TempDir(), []string{"*.sig", "*.sbom.json"})
+	if err != nil {
+		t.Errorf("downloadAssets returned err: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("downloadAssets is %v, want no files", got)
+	}
+}
+
+func TestGithubRelease_globEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "app.tar.gz", want: "app.tar.gz"},
+		{name: "app[amd64].zip", want: `app\[amd64\].zip`},
+		{name: "app*?.zip", want: `app\*\?.zip`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := globEscape(test.name)
+
+			if got != test.want {
+				t.Errorf("globEscape is %v, want %v", got, test.want)
+			}
+
+			ok, err := filepath.Match(got, test.name)
+			if err != nil || !ok {
+				t.Errorf("globEscape(%s) does not match the name: %v", test.name, err)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Create_Command_Assets(t *testing.T) {
+	// setup types
+	c := &Create{
+		Tag: "v1.0.0",
+		assets: []asset{
+			{Name: "app=linux.tar.gz", Path: "dir/app=linux.tar.gz"},
+			{Name: "app[amd64].zip", Path: "dir/app[amd64].zip"},
+		},
+	}
+
+	got := c.Command(t.Context())
+
+	for _, want := range []string{"dir/app=linux.tar.gz", "dir/app[amd64].zip"} {
+		if !slices.Contains(got.Args, want) {
+			t.Errorf("Command args is %v, want it to include %v", got.Args, want)
+		}
+	}
+}
+
+func TestGithubRelease_Copy_Commands_Tokens(t *testing.T) {
+	// setup types
+	c := &Copy{
+		Repo:           "github.com/tools/hello",
+		SourceHostname: "git.example.com",
+		SourceRepo:     "octocat/hello",
+		SourceToken:    "source",
+		Tag:            "v1.0.0",
+	}
+
+	src := release{
+		Assets:  []releaseAsset{{Name: "app.tar.gz"}},
+		TagName: "v1.0.0",
+	}
+
+	download, assets := downloadCommand(t.Context(), src, c.source(), t.TempDir(), nil)
+
+	tests := []struct {
+		name    string
+		cmd     *exec.Cmd
+		repo    string
+		token   bool
+		command string
+	}{
+		{
+			name:    "view",
+			cmd:     viewCommand(t.Context(), c.source(), c.Tag),
+			repo:    "git.example.com/octocat/hello",
+			token:   true,
+			command: viewAction,
+		},
+		{
+			name:    "download",
+			cmd:     download,
+			repo:    "git.example.com/octocat/hello",
+			token:   true,
+			command: downloadAction,
+		},
+		{
+			name:    "create",
+			cmd:     createCommand(t.Context(), src, remote{Repo: c.Repo}, c.Tag, "", assets),
+			repo:    "github.com/tools/hello",
+			command: createAction,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.cmd.Args[2] != test.command {
+				t.Errorf("Command args is %v, want a %s command", test.cmd.Args, test.command)
+			}
+
+			if !slices.Contains(test.cmd.Env, "GH_REPO="+test.repo) {
+				t.Errorf("environment does not contain GH_REPO=%s", test.repo)
+			}
+
+			// only the commands for the source host use the source token
+			for _, want := range []string{"GH_TOKEN=source", "GH_ENTERPRISE_TOKEN=source"} {
+				if slices.Contains(test.cmd.Env, want) != test.token {
+					t.Errorf("environment contains %s is %v, want %v", want, !test.token, test.token)
+				}
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Copy_Exec_Error(t *testing.T) {
+	// setup types
+	c := &Copy{
+		SourceRepo: "octocat/hello",
+		Tag:        "v1.0.0",
+	}
+
+	err := c.Exec(t.Context())
+	if err == nil {
+		t.Errorf("Exec should have returned err")
+	}
+}
+
+func TestGithubRelease_Copy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Copy
+		wantErr error
+	}{
+		{
+			name: "valid",
+			c:    &Copy{SourceRepo: "octocat/hello", Tag: "v1.0.0"},
+		},
+		{
+			name: "destination tag",
+			c: &Copy{
+				DestinationTag: "v1.0.0",
+				Policy:         &TagPolicy{Semver: true},
+				SourceRepo:     "octocat/hello",
+				Tag:            "build-123",
+			},
+		},
+		{
+			name:    "no source repo",
+			c:       &Copy{Tag: "v1.0.0"},
+			wantErr: ErrorNoCopySourceRepo,
+		},
+		{
+			name:    "no tag",
+			c:       &Copy{SourceRepo: "octocat/hello"},
+			wantErr: ErrorNoCopyTag,
+		},
+		{
+			name: "destination tag violates policy",
+			c: &Copy{
+				Policy:     &TagPolicy{Semver: true},
+				SourceRepo: "octocat/hello",
+				Tag:        "build-123",
+			},
+			wantErr: ErrorInvalidTag,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.c.Validate()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Validate error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
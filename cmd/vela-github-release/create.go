@@ -6,10 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
-	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 )
 
 const createAction = "create"
@@ -20,26 +24,76 @@ var (
 
 	// ErrorNoCreateTarget is returned when the plugin is missing the create target.
 	ErrorNoCreateTarget = errors.New("no create target provided")
+
+	// ErrorCreateChangelogNotesFile is returned when the plugin is provided a changelog with a notes file.
+	ErrorCreateChangelogNotesFile = errors.New("changelog cannot be combined with notes file")
+
+	// ErrorCreateStableBranch is returned when a stable release is created from a non-default branch target.
+	ErrorCreateStableBranch = errors.New("stable release must target the default branch")
+
+	// ErrorInvalidCreateLatest is returned when the plugin is provided an unsupported create latest.
+	ErrorInvalidCreateLatest = errors.New("invalid create latest provided")
+
+	// ErrorInvalidCreatePrerelease is returned when the plugin is provided an unsupported create prerelease.
+	ErrorInvalidCreatePrerelease = errors.New("invalid create prerelease provided")
+
+	// ErrorNoCreateGenerateNotes is returned when the plugin is provided a notes start tag without generating notes.
+	ErrorNoCreateGenerateNotes = errors.New("notes start tag provided without generate notes")
 )
 
 // Create represents the plugin configuration for Create config information.
 type Create struct {
+	// format to archive directories in before upload (tar.gz or zip)
+	Archive string
+	// template for the name of archived directories
+	ArchiveName string
+	// create release notes from the git history since the previous tag
+	Changelog bool
+	// previous tag to start the changelog from (default: latest tag reachable from the release)
+	ChangelogFrom string
+	// start a discussion in the given category for the release
+	DiscussionCategory string
 	// save the release as a draft instead of publishing it
 	Draft bool
+	// list of patterns for files to exclude from the assets
+	Exclude []string
 	// list of asset files to be given to create the release
 	Files []string
+	// automatically generate title and notes for the release
+	GenerateNotes bool
+	// mark the release as latest (true, false or auto)
+	Latest string
 	// create release notes
 	Notes string
 	// read release notes from file
 	NotesFile string
-	// mark the release as a prerelease
-	Prerelease bool
+	// tag to use as the starting point for generating release notes
+	NotesStartTag string
+	// policy the tag must satisfy
+	Policy *TagPolicy
+	// mark the release as a prerelease (a boolean or auto to infer it from the tag)
+	Prerelease string
+	// prerelease identifier to use when computing the next tag (e.g. rc)
+	PrereleaseID string
+	// refuse to create a stable release from a target other than the default branch
+	StableDefaultBranch bool
+	// directory to stage renamed assets in
+	StageDir string
+	// fail on unmatched patterns, directories, empty files and duplicate asset names
+	Strict bool
 	// tag name to create a release from
 	Tag string
 	// target branch or commit SHA (default: main branch)
 	Target string
 	// release title
 	Title string
+	// require the tag to exist in the remote repository and point at the target commit
+	VerifyTag bool
+
+	// assets resolved outside of the files, such as the assets of a copied release
+	assets []asset
+	// assets resolved from the files
+	files []asset
 }
 
 // Command formats and outputs the Create command from
@@ -62,25 +116,30 @@ func (c *Create) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, c.Tag)
 	}
 
-	// iterate through the files and add them as parameters
-	for _, file := range c.Files {
-		f, err := filepath.Glob(file)
-		if err != nil {
-			logrus.Warnf("bad file pattern: %v", err)
-		}
-
-		if f == nil {
-			logrus.Warnf("no file matches found for %s", file)
+	// add the assets resolved from the provided files as parameters
+	flags = append(flags, assetArgs(append(c.files, c.assets...))...)
 
-			continue
-		}
-
-		flags = append(flags, f...)
+	// check if create discussion category is provided
+	if len(c.DiscussionCategory) > 0 {
+		// add flag for discussion category from provided create discussion category
+		flags = append(flags, fmt.Sprintf("--discussion-category=%s", c.DiscussionCategory))
 	}
 
 	// add flag for draft from provided create draft
 	flags = append(flags, fmt.Sprintf("--draft=%t", c.Draft))
 
+	// check if create generate notes is provided
+	if c.GenerateNotes {
+		// add flag for generate notes from provided create generate notes
+		flags = append(flags, "--generate-notes")
+	}
+
+	// check if create latest is resolved
+	if latest, err := strconv.ParseBool(c.Latest); err == nil {
+		// add flag for latest from provided create latest
+		flags = append(flags, fmt.Sprintf("--latest=%t", latest))
+	}
+
 	// check if create notes is provided
 	if len(c.Notes) > 0 {
 		// add flag for notes from provided create notes
@@ -93,8 +152,14 @@ func (c *Create) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, fmt.Sprintf("--notes-file=%s", c.NotesFile))
 	}
 
+	// check if create notes start tag is provided
+	if len(c.NotesStartTag) > 0 {
+		// add flag for notes start tag from provided create notes start tag
+		flags = append(flags, fmt.Sprintf("--notes-start-tag=%s", c.NotesStartTag))
+	}
+
 	// add flag for prerelease from provided create prerelease
-	flags = append(flags, fmt.Sprintf("--prerelease=%t", c.Prerelease))
+	flags = append(flags, fmt.Sprintf("--prerelease=%t", c.isPrerelease()))
 
 	// check if create target branch is provided
 	if len(c.Target) > 0 {
@@ -108,23 +173,285 @@ func (c *Create) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, fmt.Sprintf("--title=%s", c.Title))
 	}
 
+	// check if create verify tag is provided
+	if c.VerifyTag {
+		// add flag for verify tag from provided create verify tag
+		flags = append(flags, "--verify-tag")
+	}
+
 	return exec.CommandContext(ctx, _gh, flags...)
 }
 
+// isPrerelease returns true if the release should be marked as a prerelease
+// from the provided create prerelease or the prerelease component of the tag.
+func (c *Create) isPrerelease() bool {
+	if !strings.EqualFold(c.Prerelease, autoTag) {
+		prerelease, _ := strconv.ParseBool(c.Prerelease)
+
+		return prerelease
+	}
+
+	v, err := semver.NewVersion(c.Tag)
+	if err != nil {
+		return false
+	}
+
+	return len(v.Prerelease()) > 0
+}
+
+// isLatest is a helper function to check if the provided tag is a stable
+// version newer than or equal to every existing stable release.
+func isLatest(tag string, releases []release) bool {
+	v, err := semver.NewVersion(tag)
+	if err != nil || len(v.Prerelease()) > 0 {
+		return false
+	}
+
+	_, newest := latestStable(releases)
+
+	return newest == nil || !newest.GreaterThan(v)
+}
+
+// checkStable verifies a stable release targets the default branch
+// when the create stable default branch is provided.
+func (c *Create) checkStable() error {
+	// check if the release is stable and must target the default branch
+	if !c.StableDefaultBranch || c.Draft || c.isPrerelease() {
+		return nil
+	}
+
+	// capture the default branch of the repository
+	branch := os.Getenv("VELA_REPO_BRANCH")
+	if len(branch) == 0 {
+		logrus.Warn("VELA_REPO_BRANCH not set, unable to verify stable release target")
+
+		return nil
+	}
+
+	// allow the default branch or the commit of a build for the default branch
+	if c.Target == branch ||
+		(c.Target == os.Getenv("VELA_BUILD_COMMIT") && os.Getenv("VELA_BUILD_BRANCH") == branch) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s targets %s instead of %s", ErrorCreateStableBranch, c.Tag, c.Target, branch)
+}
+
 // Exec formats and runs the commands for applying
 // the provided configuration to the resources.
 func (c *Create) Exec(ctx context.Context) error {
 	logrus.Debug("running create with provided configuration")
 
+	// check if the next tag should be computed
+	if strings.EqualFold(c.Tag, autoTag) {
+		tag, err := nextTag(ctx, c.PrereleaseID)
+		if err != nil {
+			return err
+		}
+
+		// verify the computed tag satisfies the tag policy
+		err = c.Policy.Validate(tag)
+		if err != nil {
+			return err
+		}
+
+		c.Tag = tag
+
+		// verify a stable release for the computed tag targets the default branch
+		err = c.checkStable()
+		if err != nil {
+			return err
+		}
+	}
+
+	// check if the latest release should be determined
+	if strings.EqualFold(c.Latest, autoTag) {
+		releases, err := listReleases(ctx, remote{}, _releaseLimit)
+		if err != nil {
+			return err
+		}
+
+		c.Latest = strconv.FormatBool(isLatest(c.Tag, releases))
+
+		logrus.Infof("marking release %s as latest: %s", c.Tag, c.Latest)
+	}
+
+	// check if create verify tag is provided
+	if c.VerifyTag {
+		// verify the tag exists and points at the target before creating the release
+		err := verifyTag(ctx, c.Tag, c.Target)
+		if err != nil {
+			return err
+		}
+	}
+
+	// check if create discussion category is provided
+	if len(c.DiscussionCategory) > 0 {
+		categories, err := discussionCategories(ctx)
+		if err != nil {
+			return err
+		}
+
+		// verify the discussion category exists in the repository
+		c.DiscussionCategory, err = matchCategory(c.DiscussionCategory, categories)
+		if err != nil {
+			return err
+		}
+	}
+
+	// resolve the assets once the tag used in archive names is known
+	err := c.resolve()
+	if err != nil {
+		return err
+	}
+
+	// link renamed assets and archive directories under their asset name
+	err = stageAssets(c.files)
+	if err != nil {
+		return err
+	}
+
+	// verify the assets fit the GitHub limits before creating the release
+	err = preflight(c.files, nil, c.Strict)
+	if err != nil {
+		return err
+	}
+
+	notesFile := c.NotesFile
+
+	// render the title and notes templates
+	err = c.Render()
+	if err != nil {
+		return err
+	}
+
+	// remove the rendered notes file once the release is created
+	if c.NotesFile != notesFile {
+		defer appFS.Remove(c.NotesFile)
+	}
+
+	// check if create changelog is provided
+	if c.Changelog {
+		// create the changelog from the git history in the workspace
+		notes, err := changelog(ctx, c.ChangelogFrom, c.Tag, os.Getenv("VELA_REPO_LINK"))
+		if err != nil {
+			return err
+		}
+
+		// use the provided notes as a header for the changelog
+		if len(c.Notes) > 0 {
+			notes = fmt.Sprintf("%s\n\n%s", c.Notes, notes)
+		}
+
+		c.Notes = notes
+	}
+
 	// create command for the target branch
 	cmd := c.Command(ctx)
 
 	// run the create command for the target branch
-	err := execCmd(cmd, nil)
+	err = execCmd(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// assetOptions returns the options for resolving the create assets.
+func (c *Create) assetOptions() assetOptions {
+	return assetOptions{
+		Archive:     c.Archive,
+		ArchiveName: c.ArchiveName,
+		Exclude:     c.Exclude,
+		StageDir:    c.StageDir,
+		Strict:      c.Strict,
+		Tag:         c.Tag,
+	}
+}
+
+// resolve resolves the provided files into the assets of the release.
+func (c *Create) resolve() error {
+	var err error
+
+	c.files, err = resolveAssets(c.Files, c.assetOptions())
+
+	return err
+}
+
+// readNotesFile is a helper function to return the
+// contents of the notes file, which may be a template.
+func (c *Create) readNotesFile() string {
+	// check if create notesfile is provided
+	if len(c.NotesFile) == 0 {
+		return ""
+	}
+
+	// a missing notes file is reported by gh when creating the release
+	b, err := afero.ReadFile(appFS, c.NotesFile)
+	if err != nil {
+		logrus.Debugf("unable to read notes file %s: %v", c.NotesFile, err)
+	}
+
+	return string(b)
+}
+
+// Render renders the release title, notes and notes file
+// as Go templates using the Vela build context.
+func (c *Create) Render() error {
+	logrus.Trace("rendering create templates")
+
+	// use custom filesystem which enables us to test
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	notesFile := c.readNotesFile()
+
+	// check if there is anything to render
+	if !isTemplate(c.Title, c.Notes, notesFile) {
+		return nil
+	}
+
+	data, err := newTemplateData(c.Tag, c.files)
+	if err != nil {
+		return err
+	}
+
+	c.Title, err = renderTemplate("title", c.Title, data)
+	if err != nil {
+		return err
+	}
+
+	c.Notes, err = renderTemplate("notes", c.Notes, data)
+	if err != nil {
+		return err
+	}
+
+	// check if the notes file needs to be rendered
+	if !isTemplate(notesFile) {
+		return nil
+	}
+
+	notesFile, err = renderTemplate("notes_file", notesFile, data)
+	if err != nil {
+		return err
+	}
+
+	// write the rendered notes to a new file to leave the original untouched
+	f, err := a.TempFile("", "notes-*.md")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(notesFile)
 	if err != nil {
 		return err
 	}
 
+	c.NotesFile = f.Name()
+
 	return nil
 }
 
@@ -142,5 +469,74 @@ func (c *Create) Validate() error {
 		return ErrorNoCreateTag
 	}
 
+	// verify the archive format is supported
+	err := validateArchive(c.Archive)
+	if err != nil {
+		return err
+	}
+
+	// verify the files resolve to assets
+	_, err = resolveAssets(c.Files, c.assetOptions())
+	if err != nil {
+		return err
+	}
+
+	// verify create tag satisfies the tag policy unless it is computed
+	if !strings.EqualFold(c.Tag, autoTag) {
+		err = c.Policy.Validate(c.Tag)
+		if err != nil {
+			return err
+		}
+
+		// verify a stable release targets the default branch
+		err = c.checkStable()
+		if err != nil {
+			return err
+		}
+	}
+
+	// verify create latest is supported
+	switch strings.ToLower(c.Latest) {
+	case "", "true", "false", autoTag:
+	default:
+		return fmt.Errorf("%w: %s (Valid options: true, false, %s)", ErrorInvalidCreateLatest, c.Latest, autoTag)
+	}
+
+	// verify create prerelease is a boolean or auto
+	if len(c.Prerelease) > 0 && !strings.EqualFold(c.Prerelease, autoTag) {
+		_, err = strconv.ParseBool(c.Prerelease)
+		if err != nil {
+			return fmt.Errorf("%w: %s (Valid options: true, false, %s)", ErrorInvalidCreatePrerelease, c.Prerelease, autoTag)
+		}
+	}
+
+	// verify changelog is not combined with notes file
+	if c.Changelog && len(c.NotesFile) > 0 {
+		return ErrorCreateChangelogNotesFile
+	}
+
+	// verify generate notes is enabled if notes start tag is provided
+	if len(c.NotesStartTag) > 0 && !c.GenerateNotes {
+		return ErrorNoCreateGenerateNotes
+	}
+
+	// verify the title and notes templates parse, they
+	// are only rendered when the release is created
+	templates := []struct {
+		name string
+		text string
+	}{
+		{name: "title", text: c.Title},
+		{name: "notes", text: c.Notes},
+		{name: "notes_file", text: c.readNotesFile()},
+	}
+
+	for _, t := range templates {
+		_, err = parseTemplate(t.name, t.text)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 	"testing"
+
+	"github.com/spf13/afero"
 )
 
 func TestGithubRelease_Create_Command(t *testing.T) {
@@ -16,7 +19,7 @@ func TestGithubRelease_Create_Command(t *testing.T) {
 		Files:      []string{"testdata/file"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -38,6 +41,12 @@ func TestGithubRelease_Create_Command(t *testing.T) {
 		fmt.Sprintf("--title=%s", c.Title),
 	)
 
+	// resolve the assets from the files
+	err := c.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := c.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -62,7 +71,7 @@ func TestGithubRelease_Create_Command_FileMissing(t *testing.T) {
 		Files:      []string{"testdata/file_missing"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -83,6 +92,12 @@ func TestGithubRelease_Create_Command_FileMissing(t *testing.T) {
 		fmt.Sprintf("--title=%s", c.Title),
 	)
 
+	// resolve the assets from the files
+	err := c.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := c.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -107,7 +122,7 @@ func TestGithubRelease_Create_Command_MultipleFiles(t *testing.T) {
 		Files:      []string{"testdata/test1.txt", "testdata/test2.txt"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -130,6 +145,12 @@ func TestGithubRelease_Create_Command_MultipleFiles(t *testing.T) {
 		fmt.Sprintf("--title=%s", c.Title),
 	)
 
+	// resolve the assets from the files
+	err := c.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := c.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -154,7 +175,7 @@ func TestGithubRelease_Create_Command_MultipleFilesGlob(t *testing.T) {
 		Files:      []string{"testdata/*.txt"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -177,6 +198,12 @@ func TestGithubRelease_Create_Command_MultipleFilesGlob(t *testing.T) {
 		fmt.Sprintf("--title=%s", c.Title),
 	)
 
+	// resolve the assets from the files
+	err := c.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := c.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -194,6 +221,225 @@ func TestGithubRelease_Create_Command_MultipleFilesGlob(t *testing.T) {
 	}
 }
 
+func TestGithubRelease_Create_Command_GenerateNotes(t *testing.T) {
+	// setup types
+	c := &Create{
+		GenerateNotes: true,
+		Notes:         "## Highlights",
+		NotesStartTag: "v0.9.0",
+		Tag:           "tag",
+		Target:        "target",
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		createAction,
+		"tag",
+		fmt.Sprintf("--draft=%t", false),
+		"--generate-notes",
+		fmt.Sprintf("--notes=%s", c.Notes),
+		fmt.Sprintf("--notes-start-tag=%s", c.NotesStartTag),
+		fmt.Sprintf("--prerelease=%t", false),
+		fmt.Sprintf("--target=%s", c.Target),
+	)
+
+	got := c.Command(t.Context())
+
+	if got.Path != want.Path {
+		t.Errorf("Command path is %v, want %v", got.Path, want.Path)
+	}
+
+	if len(got.Args) != len(want.Args) {
+		t.Errorf("Command args length is %v, want %v", len(got.Args), len(want.Args))
+	}
+
+	for i, arg := range got.Args {
+		if i < len(want.Args) && arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
+
+func TestGithubRelease_Create_isPrerelease(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Create
+		want bool
+	}{
+		{name: "true", c: &Create{Prerelease: "True", Tag: "v1.0.0"}, want: true},
+		{name: "short true", c: &Create{Prerelease: "t", Tag: "v1.0.0"}, want: true},
+		{name: "numeric true", c: &Create{Prerelease: "1", Tag: "v1.0.0"}, want: true},
+		{name: "false", c: &Create{Prerelease: "false", Tag: "v1.0.0-rc.1"}, want: false},
+		{name: "stable", c: &Create{Tag: "v2.0.0-rc.1"}, want: false},
+		{name: "auto prerelease", c: &Create{Prerelease: autoTag, Tag: "v2.0.0-rc.1"}, want: true},
+		{name: "auto stable", c: &Create{Prerelease: autoTag, Tag: "v2.0.0"}, want: false},
+		{name: "auto not semver", c: &Create{Prerelease: autoTag, Tag: "nightly"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.c.isPrerelease(); got != test.want {
+				t.Errorf("isPrerelease is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Create_checkStable(t *testing.T) {
+	// setup environment
+	t.Setenv("VELA_REPO_BRANCH", "main")
+	t.Setenv("VELA_BUILD_BRANCH", "main")
+	t.Setenv("VELA_BUILD_COMMIT", "abc123")
+
+	tests := []struct {
+		name    string
+		c       *Create
+		wantErr error
+	}{
+		{
+			name: "disabled",
+			c:    &Create{Tag: "v1.0.0", Target: "release/1.x"},
+		},
+		{
+			name: "default branch",
+			c:    &Create{StableDefaultBranch: true, Tag: "v1.0.0", Target: "main"},
+		},
+		{
+			name: "default branch commit",
+			c:    &Create{StableDefaultBranch: true, Tag: "v1.0.0", Target: "abc123"},
+		},
+		{
+			name: "prerelease from other branch",
+			c:    &Create{Prerelease: autoTag, StableDefaultBranch: true, Tag: "v1.0.0-rc.1", Target: "release/1.x"},
+		},
+		{
+			name:    "stable from other branch",
+			c:       &Create{Prerelease: autoTag, StableDefaultBranch: true, Tag: "v1.0.0", Target: "release/1.x"},
+			wantErr: ErrorCreateStableBranch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.c.checkStable()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("checkStable error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Create_Command_DiscussionCategory(t *testing.T) {
+	// setup types
+	c := &Create{
+		DiscussionCategory: "Announcements",
+		Tag:                "tag",
+		Target:             "target",
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		createAction,
+		"tag",
+		fmt.Sprintf("--discussion-category=%s", c.DiscussionCategory),
+		fmt.Sprintf("--draft=%t", false),
+		fmt.Sprintf("--prerelease=%t", false),
+		fmt.Sprintf("--target=%s", c.Target),
+	)
+
+	got := c.Command(t.Context())
+
+	if len(got.Args) != len(want.Args) {
+		t.Errorf("Command args length is %v, want %v", len(got.Args), len(want.Args))
+	}
+
+	for i, arg := range got.Args {
+		if i < len(want.Args) && arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
+
+func TestGithubRelease_Create_Command_VerifyTag(t *testing.T) {
+	// setup types
+	c := &Create{
+		Tag:       "tag",
+		Target:    "abc1234",
+		VerifyTag: true,
+	}
+
+	got := c.Command(t.Context())
+
+	if last := got.Args[len(got.Args)-1]; last != "--verify-tag" {
+		t.Errorf("Command last arg is %v, want --verify-tag", last)
+	}
+}
+
+func TestGithubRelease_Create_Command_Latest(t *testing.T) {
+	tests := []struct {
+		latest string
+		want   string
+	}{
+		{latest: "true", want: "--latest=true"},
+		{latest: "FALSE", want: "--latest=false"},
+		{latest: "", want: ""},
+		{latest: "auto", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.latest, func(t *testing.T) {
+			c := &Create{Latest: test.latest, Tag: "tag", Target: "target"}
+
+			var got string
+
+			for _, arg := range c.Command(t.Context()).Args {
+				if strings.HasPrefix(arg, "--latest") {
+					got = arg
+				}
+			}
+
+			if got != test.want {
+				t.Errorf("Command latest flag is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_isLatest(t *testing.T) {
+	// setup types
+	releases := []release{
+		{TagName: "v2.3.0"},
+		{TagName: "v1.9.3"},
+		{TagName: "v2.4.0-rc.1", IsPrerelease: true},
+	}
+
+	tests := []struct {
+		tag      string
+		releases []release
+		want     bool
+	}{
+		{tag: "v1.9.4", releases: releases, want: false},
+		{tag: "v2.3.1", releases: releases, want: true},
+		{tag: "v2.4.0-rc.2", releases: releases, want: false},
+		{tag: "nightly", releases: releases, want: false},
+		{tag: "v0.1.0", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			if got := isLatest(test.tag, test.releases); got != test.want {
+				t.Errorf("isLatest is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
 func TestGithubRelease_Create_Exec_Error(t *testing.T) {
 	// setup types
 	c := &Create{
@@ -201,7 +447,7 @@ func TestGithubRelease_Create_Exec_Error(t *testing.T) {
 		Files:      []string{"file"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -213,6 +459,58 @@ func TestGithubRelease_Create_Exec_Error(t *testing.T) {
 	}
 }
 
+func TestGithubRelease_Create_Render(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup environment
+	t.Setenv("VELA_BUILD_NUMBER", "42")
+
+	err := a.WriteFile("notes.md", []byte("Built in {{ .Build.Number }}"), 0600)
+	if err != nil {
+		t.Errorf("Unable to write notes file: %v", err)
+	}
+
+	// setup types
+	c := &Create{
+		Notes:     "Notes for {{ .Tag }}",
+		NotesFile: "notes.md",
+		Tag:       "v1.2.3",
+		Target:    "target",
+		Title:     "v{{ .Tag.Major }}.{{ .Tag.Minor }}",
+	}
+
+	err = c.Render()
+	if err != nil {
+		t.Errorf("Render returned err: %v", err)
+	}
+
+	if c.Title != "v1.2" {
+		t.Errorf("Title is %v, want v1.2", c.Title)
+	}
+
+	if c.Notes != "Notes for v1.2.3" {
+		t.Errorf("Notes is %v, want Notes for v1.2.3", c.Notes)
+	}
+
+	if c.NotesFile == "notes.md" {
+		t.Errorf("NotesFile should point to the rendered notes file")
+	}
+
+	got, err := a.ReadFile(c.NotesFile)
+	if err != nil {
+		t.Errorf("Unable to read rendered notes file: %v", err)
+	}
+
+	if string(got) != "Built in 42" {
+		t.Errorf("rendered notes file is %s, want Built in 42", got)
+	}
+}
+
 func TestGithubRelease_Create_Validate_Success(t *testing.T) {
 	// setup types
 	c := &Create{
@@ -220,7 +518,7 @@ func TestGithubRelease_Create_Validate_Success(t *testing.T) {
 		Files:      []string{"file"},
 		Notes:      "notes",
 		NotesFile:  "notes_file",
-		Prerelease: false,
+		Prerelease: "false",
 		Tag:        "tag",
 		Target:     "target",
 		Title:      "title",
@@ -245,7 +543,7 @@ func TestGithubRelease_Create_Validate_Error(t *testing.T) {
 				Files:      []string{"file"},
 				Notes:      "notes",
 				NotesFile:  "notes_file",
-				Prerelease: false,
+				Prerelease: "false",
 				Tag:        "tag",
 				Target:     "",
 				Title:      "title",
@@ -259,13 +557,77 @@ func TestGithubRelease_Create_Validate_Error(t *testing.T) {
 				Files:      []string{"file"},
 				Notes:      "notes",
 				NotesFile:  "notes_file",
-				Prerelease: false,
+				Prerelease: "false",
 				Tag:        "",
 				Target:     "target",
 				Title:      "title",
 			},
 			wantErr: ErrorNoCreateTag,
 		},
+		{
+			name: "Notes start tag without generate notes",
+			c: &Create{
+				NotesStartTag: "v0.9.0",
+				Tag:           "tag",
+				Target:        "target",
+			},
+			wantErr: ErrorNoCreateGenerateNotes,
+		},
+		{
+			name: "Changelog with notes file",
+			c: &Create{
+				Changelog: true,
+				NotesFile: "notes_file",
+				Tag:       "tag",
+				Target:    "target",
+			},
+			wantErr: ErrorCreateChangelogNotesFile,
+		},
+		{
+			name: "Invalid latest",
+			c: &Create{
+				Latest: "sometimes",
+				Tag:    "tag",
+				Target: "target",
+			},
+			wantErr: ErrorInvalidCreateLatest,
+		},
+		{
+			name: "Invalid prerelease",
+			c: &Create{
+				Prerelease: "yes",
+				Tag:        "tag",
+				Target:     "target",
+			},
+			wantErr: ErrorInvalidCreatePrerelease,
+		},
+		{
+			name: "Tag violates policy",
+			c: &Create{
+				Policy: &TagPolicy{Semver: true},
+				Tag:    "1.2",
+				Target: "target",
+			},
+			wantErr: ErrorInvalidTag,
+		},
+		{
+			name: "Invalid title template",
+			c: &Create{
+				Tag:    "tag",
+				Target: "target",
+				Title:  "{{ .Tag",
+			},
+			wantErr: ErrorInvalidTemplate,
+		},
+		{
+			name: "Invalid archive format",
+			c: &Create{
+				Archive: "rar",
+				Tag:     "tag",
+				Target:  "target",
+			},
+			wantErr: ErrorInvalidArchiveFormat,
+		},
 	}
 
 	for _, test := range tests {
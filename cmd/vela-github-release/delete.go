@@ -21,6 +21,8 @@ var (
 
 // Delete represents the plugin configuration for Delete config information.
 type Delete struct {
+	// delete the tag in addition to the release
+	CleanupTag bool
 	// tag name to delete a release from
 	Tag string
 	// Skip the confirmation prompt
@@ -47,6 +49,12 @@ func (d *Delete) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, d.Tag)
 	}
 
+	// check if delete cleanup tag is provided
+	if d.CleanupTag {
+		// add flag for cleanup tag from provided delete cleanup tag
+		flags = append(flags, "--cleanup-tag")
+	}
+
 	// add flag for delete from provided delete
 	flags = append(flags, fmt.Sprintf("--yes=%t", d.Yes))
 
@@ -43,6 +43,38 @@ func TestGithubRelease_Delete_Command(t *testing.T) {
 	}
 }
 
+func TestGithubRelease_Delete_Command_CleanupTag(t *testing.T) {
+	// setup types
+	d := &Delete{
+		CleanupTag: true,
+		Tag:        "tag",
+		Yes:        true,
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		deleteAction,
+		d.Tag,
+		"--cleanup-tag",
+		fmt.Sprintf("--yes=%t", true),
+	)
+
+	got := d.Command(t.Context())
+
+	if len(got.Args) != len(want.Args) {
+		t.Fatalf("Command args is %v, want %v", got.Args, want.Args)
+	}
+
+	for i, arg := range got.Args {
+		if arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
+
 func TestGithubRelease_Delete_Exec_Error(t *testing.T) {
 	// setup types
 	d := &Delete{
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// _discussionQuery is the GraphQL query to capture the discussion categories for a repository.
+const _discussionQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    discussionCategories(first: 100) {
+      nodes { name }
+    }
+  }
+}`
+
+// ErrorInvalidDiscussionCategory is returned when the discussion category does not exist in the repository.
+var ErrorInvalidDiscussionCategory = errors.New("invalid discussion category provided")
+
+// discussionCategories is a helper function to capture
+// the discussion categories for the repository from gh.
+func discussionCategories(ctx context.Context) ([]string, error) {
+	logrus.Trace("listing discussion categories with gh")
+
+	// variable to store flags for command
+	var flags []string
+
+	// add flag for api command
+	flags = append(flags, "api", "graphql")
+
+	// add flags for the repository placeholders populated by gh
+	flags = append(flags, "-F", "owner={owner}", "-F", "name={repo}")
+
+	// add flags for the query and the category names
+	flags = append(flags, "-f", fmt.Sprintf("query=%s", _discussionQuery))
+	flags = append(flags, "--jq", ".data.repository.discussionCategories.nodes[].name")
+
+	out, err := outputCmd(exec.CommandContext(ctx, _gh, flags...))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list discussion categories: %w", err)
+	}
+
+	var categories []string
+
+	for _, line := range strings.Split(string(out), "\n") {
+		if len(strings.TrimSpace(line)) > 0 {
+			categories = append(categories, strings.TrimSpace(line))
+		}
+	}
+
+	return categories, nil
+}
+
+// matchCategory is a helper function to return the discussion
+// category matching the provided name, ignoring case.
+func matchCategory(name string, categories []string) (string, error) {
+	for _, category := range categories {
+		if strings.EqualFold(category, name) {
+			return category, nil
+		}
+	}
+
+	// check if discussions are enabled for the repository
+	if len(categories) == 0 {
+		return "", fmt.Errorf("%w: %s (discussions are not enabled for the repository)", ErrorInvalidDiscussionCategory, name)
+	}
+
+	return "", fmt.Errorf("%w: %s (Valid categories: %s)", ErrorInvalidDiscussionCategory, name, strings.Join(categories, ", "))
+}
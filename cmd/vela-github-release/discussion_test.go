@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGithubRelease_matchCategory(t *testing.T) {
+	// setup types
+	categories := []string{"Announcements", "General", "Q&A"}
+
+	tests := []struct {
+		name       string
+		category   string
+		categories []string
+		want       string
+		wantErr    error
+	}{
+		{name: "exact", category: "Announcements", categories: categories, want: "Announcements"},
+		{name: "case", category: "announcements", categories: categories, want: "Announcements"},
+		{name: "missing", category: "Releases", categories: categories, wantErr: ErrorInvalidDiscussionCategory},
+		{name: "disabled", category: "Announcements", wantErr: ErrorInvalidDiscussionCategory},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := matchCategory(test.category, test.categories)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("matchCategory error = %v, wantErr = %v", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("matchCategory is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_discussionCategories_Error(t *testing.T) {
+	_, err := discussionCategories(t.Context())
+	if err == nil {
+		t.Errorf("discussionCategories should have returned err")
+	}
+}
@@ -6,12 +6,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/sirupsen/logrus"
 )
 
-const downloadAction = "download"
+const (
+	downloadAction = "download"
+
+	// existingError fails the download when a file already exists.
+	existingError = "error"
+	// existingOverwrite replaces files that already exist.
+	existingOverwrite = "overwrite"
+	// existingSameDigest keeps files that have the same sha256 digest as the asset.
+	existingSameDigest = "skip-if-same-digest"
+	// existingSameSize keeps files that have the same size as the asset.
+	existingSameSize = "skip-if-same-size"
+	// existingSkip keeps files that already exist.
+	existingSkip = "skip"
+)
 
 var (
 	// ErrorNoDownloadDirectory is returned when no download directory is provided.
@@ -19,14 +36,74 @@ var (
 
 	// ErrorNoDownloadTag is returned when the plugin is missing the download tag.
 	ErrorNoDownloadTag = errors.New("no download tag provided")
+
+	// ErrorNoDownloadRelease is returned when no release satisfies a download constraint.
+	ErrorNoDownloadRelease = errors.New("no release satisfies the download constraint")
+
+	// ErrorNoDownloadAsset is returned when a download pattern matches no asset of the release.
+	ErrorNoDownloadAsset = errors.New("no release asset matches the download pattern")
+
+	// ErrorDownloadFileExists is returned when a downloaded file already exists in the download directory.
+	ErrorDownloadFileExists = errors.New("file already exists in the download directory")
+
+	// ErrorInvalidDownloadExisting is returned when the plugin is provided an unsupported download existing policy.
+	ErrorInvalidDownloadExisting = errors.New("invalid download existing provided")
+
+	// ErrorNoDownloadSourceArchive is returned when the plugin is provided source only without source archives.
+	ErrorNoDownloadSourceArchive = errors.New("source only provided without source archives")
+
+	// ErrorInvalidDownloadStripComponents is returned when the plugin is provided negative strip components.
+	ErrorInvalidDownloadStripComponents = errors.New("invalid download strip components provided")
 )
 
+// DownloadEntry represents a release to download along with other releases.
+type DownloadEntry struct {
+	// subdirectory of the download directory to download files into
+	Dir string `json:"dir"`
+	// download only assets that match a glob pattern (default: download patterns)
+	Patterns []string `json:"patterns"`
+	// repository in the [HOST/]OWNER/REPO format (default: current repository)
+	Repo string `json:"repo"`
+	// tag name or semantic version constraint of the release to download
+	Tag string `json:"tag"`
+}
+
+// downloadResult represents the decision made for an asset of a release.
+type downloadResult struct {
+	// description of the decision for the summary
+	Decision string
+	// name of the asset
+	Name string
+	// asset is kept as is instead of downloaded
+	Skip bool
+	// asset is a source archive of the release
+	Source bool
+}
+
 // Download represents the plugin configuration for Download config information.
 type Download struct {
+	// delete archives after they are extracted
+	DeleteArchive bool
 	// the directory to download files into (default ".")
 	Directory string
+	// policy for files that already exist in the directory (error, skip, overwrite, skip-if-same-size or skip-if-same-digest)
+	Existing string
+	// extract downloaded .tar.gz, .tgz, .tar.xz and .zip assets
+	Extract bool
+	// directory to extract all archives into (default: a subdirectory of the directory per archive)
+	ExtractDir string
 	// download only assets that match a glob pattern
 	Patterns []string
+	// list of releases to download instead of the tag
+	Releases []DownloadEntry
+	// repository in the [HOST/]OWNER/REPO format (default: current repository)
+	Repo string
+	// list of formats to download the source archive in (tar.gz or zip)
+	SourceArchives []string
+	// download only the source archives instead of the assets
+	SourceOnly bool
+	// number of leading path components to remove from extracted entries
+	StripComponents int
 	// tag name to download a release from
 	Tag string
 }
@@ -60,19 +137,447 @@ func (d *Download) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, fmt.Sprintf("--pattern=%s", pattern))
 	}
 
+	// add flag for existing files from provided download existing
+	flags = append(flags, d.existingFlags()...)
+
 	return exec.CommandContext(ctx, _gh, flags...)
 }
 
+// existingFlags is a helper function to return the gh flags
+// for files that already exist in the download directory.
+func (d *Download) existingFlags() []string {
+	switch strings.ToLower(d.Existing) {
+	case existingOverwrite, existingSameDigest, existingSameSize:
+		return []string{"--clobber"}
+	case existingSkip:
+		return []string{"--skip-existing"}
+	default:
+		return nil
+	}
+}
+
+// SourceCommand formats and outputs the Download command from the
+// provided configuration to download the source archive in the format.
+func (d *Download) SourceCommand(ctx context.Context, format string) *exec.Cmd {
+	logrus.Trace("creating gh download source archive command from plugin configuration")
+
+	// variable to store flags for command
+	var flags []string
+
+	// add flag for release command
+	flags = append(flags, releaseCmd)
+
+	// add flag for download command
+	flags = append(flags, downloadAction)
+
+	// check if download tag is provided
+	if len(d.Tag) > 0 {
+		// add flag for tag from provided download tag
+		flags = append(flags, d.Tag)
+	}
+
+	// add flag for output file from the name of the source archive
+	flags = append(flags, fmt.Sprintf("--output=%s", filepath.Join(d.Directory, d.sourceArchiveName(format))))
+
+	// add flag for archive from provided source archive format
+	flags = append(flags, fmt.Sprintf("--archive=%s", format))
+
+	// add flag for existing files from provided download existing
+	flags = append(flags, d.existingFlags()...)
+
+	return exec.CommandContext(ctx, _gh, flags...)
+}
+
+// Exec formats and runs the commands for applying
+// the provided configuration to the resources.
 func (d *Download) Exec(ctx context.Context) error {
 	logrus.Debug("running download with provided configuration")
 
-	// download command for the directory
-	cmd := d.Command(ctx)
+	downloads := []*Download{d}
+
+	// check if multiple releases should be downloaded
+	if len(d.Releases) > 0 {
+		downloads = nil
+
+		for _, e := range d.Releases {
+			sub, err := d.entry(ctx, e)
+			if err != nil {
+				return err
+			}
+
+			downloads = append(downloads, sub)
+		}
+	}
+
+	var summary []string
+
+	for _, sub := range downloads {
+		results, err := sub.download(ctx)
+		if err != nil {
+			return err
+		}
+
+		var assets, skipped, sources, sourcesSkipped int
+
+		for _, r := range results {
+			switch {
+			case r.Source && r.Skip:
+				sourcesSkipped++
+			case r.Source:
+				sources++
+			case r.Skip:
+				skipped++
+			default:
+				assets++
+			}
+		}
+
+		repo := sub.Repo
+		if len(repo) == 0 {
+			repo = "current repository"
+		}
+
+		summary = append(summary, fmt.Sprintf(
+			"%s %s: %d asset(s) downloaded, %d skipped, %d source archive(s) downloaded, %d skipped into %s",
+			repo, sub.Tag, assets, skipped, sources, sourcesSkipped, sub.Directory,
+		))
+
+		for _, r := range results {
+			summary = append(summary, fmt.Sprintf("  %s: %s", r.Name, r.Decision))
+		}
+	}
+
+	fmt.Printf("downloaded %d release(s):\n", len(downloads))
+
+	for _, line := range summary {
+		fmt.Printf("  %s\n", line)
+	}
+
+	return nil
+}
+
+// entry is a helper function to return the download configuration for
+// the entry, resolving a semantic version constraint to the newest release.
+func (d *Download) entry(ctx context.Context, e DownloadEntry) (*Download, error) {
+	sub := *d
+
+	sub.Directory = filepath.Join(d.Directory, e.Dir)
+	sub.Releases = nil
+	sub.Repo = e.Repo
+	sub.Tag = e.Tag
+
+	if len(e.Patterns) > 0 {
+		sub.Patterns = e.Patterns
+	}
+
+	// check if the tag is a semantic version constraint
+	c, ok := constraint(e.Tag)
+	if !ok {
+		return &sub, nil
+	}
+
+	releases, err := listReleases(ctx, remote{Repo: e.Repo}, _releaseLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var newest *semver.Version
+
+	for _, r := range releases {
+		v := r.Version()
+		if r.IsDraft || v == nil || !c.Check(v) {
+			continue
+		}
+
+		if newest == nil || v.GreaterThan(newest) {
+			newest, sub.Tag = v, r.TagName
+		}
+	}
+
+	if newest == nil {
+		return nil, fmt.Errorf("%w: %s", ErrorNoDownloadRelease, e.Tag)
+	}
+
+	logrus.Infof("resolved %s to release %s", e.Tag, sub.Tag)
+
+	return &sub, nil
+}
+
+// constraint is a helper function to parse the tag as a semantic
+// version constraint, returning false for versions and plain tags.
+func constraint(tag string) (*semver.Constraints, bool) {
+	if _, err := semver.NewVersion(tag); err == nil {
+		return nil, false
+	}
+
+	c, err := semver.NewConstraint(tag)
+	if err != nil {
+		return nil, false
+	}
+
+	return c, true
+}
+
+// download is a helper function to download the assets matching the
+// patterns and the source archives, returning the decision for each asset.
+func (d *Download) download(ctx context.Context) ([]downloadResult, error) {
+	var results []downloadResult
 
-	// run the download command for the directory
-	err := execCmd(cmd, nil)
+	// check if the assets should be downloaded
+	if !d.SourceOnly {
+		r, err := viewRelease(ctx, remote{Repo: d.Repo}, d.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		matched, err := d.match(r.Assets)
+		if err != nil {
+			return nil, err
+		}
+
+		// decide which assets to download based on the existing files
+		results, err = d.decide(matched)
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+
+		for _, result := range results {
+			if !result.Skip {
+				names = append(names, result.Name)
+			}
+		}
+
+		// check if there is anything left to download
+		if len(names) > 0 {
+			sub := *d
+			sub.Patterns = nil
+
+			// escape the names so each pattern only matches its own asset
+			for _, name := range names {
+				sub.Patterns = append(sub.Patterns, globEscape(name))
+			}
+
+			// download command for the directory
+			cmd := withRepo(sub.Command(ctx), d.Repo)
+
+			// run the download command for the directory
+			err = execCmd(cmd, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// check if the downloaded archives should be extracted
+		if d.Extract {
+			err = d.extract(names)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var archives []releaseAsset
+
+	// the size and digest of a source archive are unknown before it is downloaded
+	for _, format := range d.SourceArchives {
+		archives = append(archives, releaseAsset{Name: d.sourceArchiveName(format), Size: -1})
+	}
+
+	// decide which source archives to download based on the existing files
+	sources, err := d.decide(archives)
+	if err != nil {
+		return nil, err
+	}
+
+	// iterate through the source archive formats
+	for i, format := range d.SourceArchives {
+		sources[i].Source = true
+
+		// check if the source archive is kept as is
+		if sources[i].Skip {
+			continue
+		}
+
+		// run the download command for the source archive
+		err := execCmd(withRepo(d.SourceCommand(ctx, format), d.Repo), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(results, sources...), nil
+}
+
+// sourceArchiveName is a helper function to return the file name GitHub
+// gives the source archive of the release in the format, which is the
+// repository name and the tag without a leading "v" before a version.
+func (d *Download) sourceArchiveName(format string) string {
+	repo := os.Getenv("VELA_REPO_NAME")
+	if len(d.Repo) > 0 {
+		repo = d.Repo[strings.LastIndex(d.Repo, "/")+1:]
+	}
+
+	tag := d.Tag
+	if len(tag) > 1 && (tag[0] == 'v' || tag[0] == 'V') && tag[1] >= '0' && tag[1] <= '9' {
+		tag = tag[1:]
+	}
+
+	return fmt.Sprintf("%s-%s.%s", repo, tag, format)
+}
+
+// decide is a helper function to apply the existing policy to the
+// provided assets, reporting every file that already exists together
+// when the policy is to error.
+func (d *Download) decide(assets []releaseAsset) ([]downloadResult, error) {
+	var (
+		errs    []error
+		results []downloadResult
+	)
+
+	for _, a := range assets {
+		path := filepath.Join(d.Directory, a.Name)
+		result := downloadResult{Decision: "downloaded", Name: a.Name}
+
+		info, err := os.Stat(path)
+		if err == nil {
+			switch strings.ToLower(d.Existing) {
+			case existingSkip:
+				result.Decision, result.Skip = "skipped (exists)", true
+			case existingOverwrite:
+				result.Decision = "overwritten"
+			case existingSameSize:
+				result.Decision = "overwritten (size differs)"
+
+				switch {
+				case a.Size < 0:
+					result.Decision = "overwritten (no size recorded)"
+				case info.Size() == a.Size:
+					result.Decision, result.Skip = "skipped (same size)", true
+				}
+			case existingSameDigest:
+				result.Decision, result.Skip, err = sameDigest(path, a)
+				if err != nil {
+					return nil, err
+				}
+			default:
+				errs = append(errs, fmt.Errorf("%w: %s", ErrorDownloadFileExists, path))
+
+				continue
+			}
+		}
+
+		logrus.Infof("asset %s: %s", a.Name, result.Decision)
+
+		results = append(results, result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// sameDigest is a helper function to compare the digest of the file at
+// path with the digest of the asset, returning the decision for the asset.
+func sameDigest(path string, a releaseAsset) (string, bool, error) {
+	remote := strings.TrimPrefix(a.Digest, "sha256:")
+
+	// assets uploaded before GitHub recorded digests are always downloaded
+	if len(remote) == 0 {
+		return "overwritten (no digest recorded)", false, nil
+	}
+
+	local, err := fileDigest(path)
 	if err != nil {
-		return err
+		return "", false, err
+	}
+
+	if !strings.EqualFold(local, remote) {
+		return "overwritten (digest differs)", false, nil
+	}
+
+	return "skipped (same digest)", true, nil
+}
+
+// match is a helper function to return the assets matching the
+// download patterns, failing when a pattern matches no asset.
+func (d *Download) match(assets []releaseAsset) ([]releaseAsset, error) {
+	var matched []releaseAsset
+
+	for _, a := range assets {
+		if d.matches(a.Name) {
+			matched = append(matched, a)
+		}
+	}
+
+	// verify each download pattern matches an asset
+	for _, pattern := range d.Patterns {
+		ok := false
+
+		for _, a := range matched {
+			if m, err := filepath.Match(pattern, a.Name); err == nil && m {
+				ok = true
+
+				break
+			}
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrorNoDownloadAsset, pattern)
+		}
+	}
+
+	return matched, nil
+}
+
+// matches is a helper function to check if the asset
+// name matches the download patterns.
+func (d *Download) matches(name string) bool {
+	// gh downloads every asset when no patterns are provided
+	if len(d.Patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range d.Patterns {
+		ok, err := filepath.Match(pattern, name)
+		if err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extract is a helper function to extract the downloaded
+// archives and optionally delete them afterwards.
+func (d *Download) extract(names []string) error {
+	for _, name := range names {
+		ext := archiveExtension(name)
+		if len(ext) == 0 {
+			continue
+		}
+
+		src := filepath.Join(d.Directory, name)
+
+		// extract each archive into its own directory unless a directory is provided
+		dst := d.ExtractDir
+		if len(dst) == 0 {
+			dst = filepath.Join(d.Directory, name[:len(name)-len(ext)])
+		}
+
+		err := extractArchive(src, dst, d.StripComponents)
+		if err != nil {
+			return err
+		}
+
+		// check if the archive should be deleted
+		if d.DeleteArchive {
+			logrus.Debugf("deleting archive %s", src)
+
+			err := os.Remove(src)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -87,10 +592,55 @@ func (d *Download) Validate() error {
 		return ErrorNoDownloadDirectory
 	}
 
-	// verify download tag is provided if no tag provided error
-	if len(d.Tag) == 0 {
+	// verify download tag is provided if no tag or releases provided error
+	if len(d.Tag) == 0 && len(d.Releases) == 0 {
 		return ErrorNoDownloadTag
 	}
 
+	// verify each download release provides a tag
+	for i, e := range d.Releases {
+		if len(e.Tag) == 0 {
+			return fmt.Errorf("%w: releases[%d]", ErrorNoDownloadTag, i)
+		}
+	}
+
+	// verify the source archive formats are supported
+	for _, format := range d.SourceArchives {
+		if len(format) == 0 {
+			return fmt.Errorf("%w: empty source archive format", ErrorInvalidArchiveFormat)
+		}
+
+		err := validateArchive(format)
+		if err != nil {
+			return err
+		}
+	}
+
+	// verify download existing is supported
+	switch strings.ToLower(d.Existing) {
+	case "", existingError, existingOverwrite, existingSameDigest, existingSameSize, existingSkip:
+	default:
+		return fmt.Errorf(
+			"%w: %s (Valid policies: %s, %s, %s, %s, %s)",
+			ErrorInvalidDownloadExisting,
+			d.Existing,
+			existingError,
+			existingSkip,
+			existingOverwrite,
+			existingSameSize,
+			existingSameDigest,
+		)
+	}
+
+	// verify source archives are provided when only downloading source archives
+	if d.SourceOnly && len(d.SourceArchives) == 0 {
+		return ErrorNoDownloadSourceArchive
+	}
+
+	// verify download strip components is not negative
+	if d.StripComponents < 0 {
+		return fmt.Errorf("%w: %d", ErrorInvalidDownloadStripComponents, d.StripComponents)
+	}
+
 	return nil
 }
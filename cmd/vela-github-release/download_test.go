@@ -5,7 +5,10 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -45,6 +48,144 @@ func TestGithubRelease_Download_Command(t *testing.T) {
 	}
 }
 
+func TestGithubRelease_Download_SourceCommand(t *testing.T) {
+	// setup types
+	d := &Download{
+		Directory:      "dir",
+		Patterns:       []string{"pattern"},
+		Repo:           "octocat/hello-world",
+		SourceArchives: []string{"zip"},
+		Tag:            "v1.0.0",
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		downloadAction,
+		d.Tag,
+		fmt.Sprintf("--output=%s", filepath.Join(d.Directory, "hello-world-1.0.0.zip")),
+		"--archive=zip",
+	)
+
+	got := d.SourceCommand(t.Context(), "zip")
+
+	if len(got.Args) != len(want.Args) {
+		t.Fatalf("Command args is %v, want %v", got.Args, want.Args)
+	}
+
+	for i, arg := range got.Args {
+		if arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
+
+func TestGithubRelease_Download_existingFlags(t *testing.T) {
+	tests := []struct {
+		existing string
+		want     []string
+	}{
+		{existing: "", want: nil},
+		{existing: existingError, want: nil},
+		{existing: existingSkip, want: []string{"--skip-existing"}},
+		{existing: existingOverwrite, want: []string{"--clobber"}},
+		{existing: existingSameSize, want: []string{"--clobber"}},
+		{existing: existingSameDigest, want: []string{"--clobber"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.existing, func(t *testing.T) {
+			d := &Download{Existing: test.existing}
+
+			got := d.existingFlags()
+
+			if len(got) != len(test.want) {
+				t.Fatalf("existingFlags is %v, want %v", got, test.want)
+			}
+
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("existingFlags[%d] is %v, want %v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Download_decide(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "app"), []byte("app"), 0600)
+	if err != nil {
+		t.Fatalf("Unable to write file: %v", err)
+	}
+
+	digest, err := fileDigest(filepath.Join(dir, "app"))
+	if err != nil {
+		t.Fatalf("Unable to digest file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		existing string
+		asset    releaseAsset
+		want     string
+		skip     bool
+	}{
+		{name: "missing", existing: existingSkip, asset: releaseAsset{Name: "other"}, want: "downloaded"},
+		{name: "skip", existing: existingSkip, asset: releaseAsset{Name: "app"}, want: "skipped (exists)", skip: true},
+		{name: "overwrite", existing: existingOverwrite, asset: releaseAsset{Name: "app"}, want: "overwritten"},
+		{name: "same size", existing: existingSameSize, asset: releaseAsset{Name: "app", Size: 3}, want: "skipped (same size)", skip: true},
+		{name: "size differs", existing: existingSameSize, asset: releaseAsset{Name: "app", Size: 4}, want: "overwritten (size differs)"},
+		{name: "no size", existing: existingSameSize, asset: releaseAsset{Name: "app", Size: -1}, want: "overwritten (no size recorded)"},
+		{name: "same digest", existing: existingSameDigest, asset: releaseAsset{Digest: "sha256:" + digest, Name: "app"}, want: "skipped (same digest)", skip: true},
+		{name: "digest differs", existing: existingSameDigest, asset: releaseAsset{Digest: "sha256:abc", Name: "app"}, want: "overwritten (digest differs)"},
+		{name: "no digest", existing: existingSameDigest, asset: releaseAsset{Name: "app"}, want: "overwritten (no digest recorded)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Download{Directory: dir, Existing: test.existing}
+
+			got, err := d.decide([]releaseAsset{test.asset})
+			if err != nil {
+				t.Fatalf("decide returned err: %v", err)
+			}
+
+			if len(got) != 1 || got[0].Decision != test.want || got[0].Skip != test.skip {
+				t.Errorf("decide is %v, want %s (skip %v)", got, test.want, test.skip)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Download_decide_Error(t *testing.T) {
+	// setup filesystem
+	dir := t.TempDir()
+
+	for _, name := range []string{"app", "lib"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0600)
+		if err != nil {
+			t.Fatalf("Unable to write file: %v", err)
+		}
+	}
+
+	d := &Download{Directory: dir}
+
+	_, err := d.decide([]releaseAsset{{Name: "app"}, {Name: "lib"}, {Name: "other"}})
+	if !errors.Is(err, ErrorDownloadFileExists) {
+		t.Fatalf("decide error = %v, wantErr = %v", err, ErrorDownloadFileExists)
+	}
+
+	for _, name := range []string{"app", "lib"} {
+		if !strings.Contains(err.Error(), filepath.Join(dir, name)) {
+			t.Errorf("decide error = %v, want it to report %s", err, name)
+		}
+	}
+}
+
 func TestGithubRelease_Download_Exec_Error(t *testing.T) {
 	// setup types
 	d := &Download{
@@ -97,6 +238,43 @@ func TestGithubRelease_Download_Validate_Error(t *testing.T) {
 			},
 			wantErr: ErrorNoDownloadTag,
 		},
+		{
+			name: "Invalid source archive format",
+			d: &Download{
+				Directory:      "dir",
+				SourceArchives: []string{"rar"},
+				Tag:            "tag",
+			},
+			wantErr: ErrorInvalidArchiveFormat,
+		},
+		{
+			name: "Source only without source archives",
+			d: &Download{
+				Directory:  "dir",
+				SourceOnly: true,
+				Tag:        "tag",
+			},
+			wantErr: ErrorNoDownloadSourceArchive,
+		},
+		{
+			name: "Invalid existing policy",
+			d: &Download{
+				Directory: "dir",
+				Existing:  "replace",
+				Tag:       "tag",
+			},
+			wantErr: ErrorInvalidDownloadExisting,
+		},
+		{
+			name: "Negative strip components",
+			d: &Download{
+				Directory:       "dir",
+				Extract:         true,
+				StripComponents: -1,
+				Tag:             "tag",
+			},
+			wantErr: ErrorInvalidDownloadStripComponents,
+		},
 	}
 
 	for _, test := range tests {
@@ -109,3 +287,196 @@ func TestGithubRelease_Download_Validate_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestGithubRelease_Download_matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     bool
+	}{
+		{name: "no patterns", want: true},
+		{name: "matching pattern", patterns: []string{"*.txt", "*.tar.gz"}, want: true},
+		{name: "no matching pattern", patterns: []string{"*.zip"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Download{Patterns: test.patterns}
+
+			got := d.matches("app_linux_amd64.tar.gz")
+			if got != test.want {
+				t.Errorf("matches is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Download_match(t *testing.T) {
+	// setup types
+	assets := []releaseAsset{{Name: "app.tar.gz"}, {Name: "app[1].zip"}, {Name: "notes.txt"}}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     int
+		wantErr  bool
+	}{
+		{name: "no patterns", want: 3},
+		{name: "matching patterns", patterns: []string{"*.tar.gz", "*.zip"}, want: 2},
+		{name: "escaped name", patterns: []string{globEscape("app[1].zip")}, want: 1},
+		{name: "unmatched pattern", patterns: []string{"*.tar.gz", "*.deb"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Download{Patterns: test.patterns}
+
+			got, err := d.match(assets)
+
+			if test.wantErr {
+				if !errors.Is(err, ErrorNoDownloadAsset) {
+					t.Errorf("match error = %v, wantErr = %v", err, ErrorNoDownloadAsset)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("match returned err: %v", err)
+			}
+
+			if len(got) != test.want {
+				t.Errorf("match is %v, want %d asset(s)", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Download_sourceArchiveName(t *testing.T) {
+	t.Setenv("VELA_REPO_NAME", "current")
+
+	tests := []struct {
+		name string
+		repo string
+		tag  string
+		want string
+	}{
+		{name: "version tag", repo: "octocat/hello-world", tag: "v1.0.0", want: "hello-world-1.0.0.tar.gz"},
+		{name: "plain tag", repo: "octocat/hello-world", tag: "latest", want: "hello-world-latest.tar.gz"},
+		{name: "current repository", tag: "v2", want: "current-2.tar.gz"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Download{Repo: test.repo, Tag: test.tag}
+
+			got := d.sourceArchiveName("tar.gz")
+			if got != test.want {
+				t.Errorf("sourceArchiveName is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_constraint(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: "v1.2.3", want: false},
+		{tag: "1.2.3-rc.1", want: false},
+		{tag: "nightly", want: false},
+		{tag: ">=1.2, <2", want: true},
+		{tag: "~1.4", want: true},
+		{tag: "1.x", want: true},
+	}
+
+	for _, test := range tests {
+		_, got := constraint(test.tag)
+
+		if got != test.want {
+			t.Errorf("constraint(%q) is %v, want %v", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestGithubRelease_Download_entry(t *testing.T) {
+	// setup types
+	d := &Download{
+		Directory: "deps",
+		Extract:   true,
+		Patterns:  []string{"*.tar.gz"},
+		Releases:  []DownloadEntry{{Tag: "v1.0.0"}},
+	}
+
+	tests := []struct {
+		name string
+		e    DownloadEntry
+		want *Download
+	}{
+		{
+			name: "inherited patterns",
+			e:    DownloadEntry{Dir: "api", Repo: "octocat/api", Tag: "v1.2.3"},
+			want: &Download{Directory: "deps/api", Extract: true, Patterns: []string{"*.tar.gz"}, Repo: "octocat/api", Tag: "v1.2.3"},
+		},
+		{
+			name: "own patterns",
+			e:    DownloadEntry{Dir: "web", Patterns: []string{"*.zip"}, Tag: "nightly"},
+			want: &Download{Directory: "deps/web", Extract: true, Patterns: []string{"*.zip"}, Tag: "nightly"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := d.entry(t.Context(), test.e)
+			if err != nil {
+				t.Fatalf("entry returned err: %v", err)
+			}
+
+			if got.Directory != test.want.Directory || got.Repo != test.want.Repo || got.Tag != test.want.Tag || !got.Extract {
+				t.Errorf("entry is %+v, want %+v", got, test.want)
+			}
+
+			if len(got.Patterns) != 1 || got.Patterns[0] != test.want.Patterns[0] {
+				t.Errorf("entry patterns are %v, want %v", got.Patterns, test.want.Patterns)
+			}
+
+			if len(got.Releases) != 0 {
+				t.Errorf("entry should not have releases")
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Download_Exec_Releases_Error(t *testing.T) {
+	// setup types
+	d := &Download{
+		Directory: "dir",
+		Releases:  []DownloadEntry{{Repo: "octocat/hello", Tag: ">=1.0"}},
+	}
+
+	err := d.Exec(t.Context())
+	if err == nil {
+		t.Errorf("Exec should have returned err")
+	}
+}
+
+func TestGithubRelease_Download_Validate_Releases(t *testing.T) {
+	// setup types
+	d := &Download{
+		Directory: "dir",
+		Releases:  []DownloadEntry{{Tag: "v1.0.0"}, {Tag: ">=2.0"}},
+	}
+
+	err := d.Validate()
+	if err != nil {
+		t.Errorf("Validate returned err: %v", err)
+	}
+
+	d.Releases = append(d.Releases, DownloadEntry{Dir: "missing"})
+
+	err = d.Validate()
+	if !errors.Is(err, ErrorNoDownloadTag) {
+		t.Errorf("Validate error = %v, wantErr = %v", err, ErrorNoDownloadTag)
+	}
+}
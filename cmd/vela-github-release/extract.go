@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ulikunitz/xz"
+)
+
+var (
+	// ErrorUnsafeArchivePath is returned when an archive entry would be extracted outside the destination.
+	ErrorUnsafeArchivePath = errors.New("archive entry escapes the extraction directory")
+
+	// _archiveExtensions are the extensions of the archives that can be extracted.
+	_archiveExtensions = []string{".tar.gz", ".tgz", ".tar.xz", ".zip"}
+)
+
+// archiveExtension is a helper function to return the archive
+// extension of the provided file name or an empty string.
+func archiveExtension(name string) string {
+	for _, ext := range _archiveExtensions {
+		if strings.HasSuffix(strings.ToLower(name), ext) {
+			return ext
+		}
+	}
+
+	return ""
+}
+
+// entryPath is a helper function to return the path, relative to the
+// destination, an archive entry is extracted to after stripping the leading
+// path components. It returns an empty path for entries removed entirely
+// by stripping.
+func entryPath(name string, strip int) (string, error) {
+	parts := strings.Split(strings.Trim(filepath.ToSlash(name), "/"), "/")
+	if len(parts) <= strip {
+		return "", nil
+	}
+
+	rel := filepath.FromSlash(strings.Join(parts[strip:], "/"))
+
+	// verify the entry stays within the destination
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%w: %s", ErrorUnsafeArchivePath, name)
+	}
+
+	return rel, nil
+}
+
+// linkTarget is a helper function to verify the symbolic link at path
+// pointing to target stays within the destination. The path and target
+// are walked one component at a time, rejecting links that resolve
+// through a link already extracted, since chained links like S -> . and
+// M -> S/.. would otherwise escape while looking local.
+func linkTarget(root *os.Root, path, target string) error {
+	unsafe := fmt.Errorf("%w: %s links to %s", ErrorUnsafeArchivePath, path, target)
+
+	if filepath.IsAbs(target) {
+		return unsafe
+	}
+
+	parts := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+	parts = append(parts, strings.Split(filepath.ToSlash(target), "/")...)
+
+	cur := "."
+
+	for _, part := range parts {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if cur == "." {
+				return unsafe
+			}
+
+			cur = filepath.Dir(cur)
+		default:
+			cur = filepath.Join(cur, part)
+
+			info, err := root.Lstat(cur)
+			if err == nil && info.Mode()&fs.ModeSymlink != 0 {
+				return fmt.Errorf("%w: %s links through %s", ErrorUnsafeArchivePath, path, cur)
+			}
+		}
+	}
+
+	return nil
+}
+
+// extractArchive extracts the archive at src into the dst directory,
+// removing the provided number of leading path components from entries.
+func extractArchive(src, dst string, strip int) error {
+	logrus.Infof("extracting %s to %s", src, dst)
+
+	err := os.MkdirAll(dst, 0755)
+	if err != nil {
+		return err
+	}
+
+	// confine every write to the destination, even through symbolic links
+	root, err := os.OpenRoot(dst)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	if archiveExtension(src) == ".zip" {
+		return extractZip(src, root, strip)
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader
+
+	switch archiveExtension(src) {
+	case ".tar.xz":
+		r, err = xz.NewReader(f)
+	default:
+		r, err = gzip.NewReader(f)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", src, err)
+	}
+
+	return extractTar(r, root, strip)
+}
+
+// extractTar is a helper function to extract the tar stream into root.
+func extractTar(r io.Reader, root *os.Root, strip int) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		path, err := entryPath(hdr.Name, strip)
+		if err != nil {
+			return err
+		}
+
+		if len(path) == 0 {
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = root.MkdirAll(path, 0755)
+		case tar.TypeReg:
+			err = writeEntry(root, path, tr, fs.FileMode(hdr.Mode).Perm())
+		case tar.TypeSymlink:
+			err = linkTarget(root, path, hdr.Linkname)
+			if err == nil {
+				err = writeLink(root, path, hdr.Linkname)
+			}
+		default:
+			logrus.Warnf("skipping unsupported archive entry %s", hdr.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip is a helper function to extract the zip archive at src into root.
+func extractZip(src string, root *os.Root, strip int) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", src, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		path, err := entryPath(f.Name, strip)
+		if err != nil {
+			return err
+		}
+
+		if len(path) == 0 {
+			continue
+		}
+
+		mode := f.Mode()
+
+		switch {
+		case mode.IsDir():
+			err = root.MkdirAll(path, 0755)
+		case mode.IsRegular():
+			err = extractZipFile(root, f, path)
+		default:
+			logrus.Warnf("skipping unsupported archive entry %s", f.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractZipFile is a helper function to extract the zip entry to path.
+func extractZipFile(root *os.Root, f *zip.File, path string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return writeEntry(root, path, rc, f.Mode().Perm())
+}
+
+// writeEntry is a helper function to write the contents of r to path with the mode.
+func writeEntry(root *os.Root, path string, r io.Reader, mode fs.FileMode) error {
+	err := root.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// never write a file through a symbolic link
+	info, err := root.Lstat(path)
+	if err == nil && info.Mode()&fs.ModeSymlink != 0 {
+		return fmt.Errorf("%w: %s is a symbolic link", ErrorUnsafeArchivePath, path)
+	}
+
+	// never extract entries without read and write access for the owner
+	f, err := root.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode|0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeLink is a helper function to create a symbolic link at path to target.
+func writeLink(root *os.Root, path, target string) error {
+	err := root.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// replace an entry left behind by a previous extraction
+	err = root.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return root.Symlink(target, path)
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+// tarEntry represents an entry written to a test archive.
+type tarEntry struct {
+	name string
+	body string
+	link string
+}
+
+// writeTestTar is a helper function to write the entries
+// as a tar stream to w, closing w afterwards.
+func writeTestTar(t *testing.T, w io.WriteCloser, entries []tarEntry) {
+	t.Helper()
+
+	tw := tar.NewWriter(w)
+
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+
+		if len(e.link) > 0 {
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.link
+		}
+
+		err := tw.WriteHeader(hdr)
+		if err != nil {
+			t.Fatalf("Unable to write tar header: %v", err)
+		}
+
+		_, err = tw.Write([]byte(e.body))
+		if err != nil {
+			t.Fatalf("Unable to write tar entry: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Unable to close tar: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unable to close archive: %v", err)
+	}
+}
+
+// createTestArchive is a helper function to create an archive
+// with the entries in the format of the file name extension.
+func createTestArchive(t *testing.T, name string, entries []tarEntry) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Unable to create archive: %v", err)
+	}
+	defer f.Close()
+
+	switch archiveExtension(name) {
+	case ".zip":
+		zw := zip.NewWriter(f)
+
+		for _, e := range entries {
+			w, err := zw.Create(e.name)
+			if err != nil {
+				t.Fatalf("Unable to write zip entry: %v", err)
+			}
+
+			_, err = w.Write([]byte(e.body))
+			if err != nil {
+				t.Fatalf("Unable to write zip entry: %v", err)
+			}
+		}
+
+		if err := zw.Close(); err != nil {
+			t.Fatalf("Unable to close zip: %v", err)
+		}
+	case ".tar.xz":
+		xw, err := xz.NewWriter(f)
+		if err != nil {
+			t.Fatalf("Unable to create xz writer: %v", err)
+		}
+
+		writeTestTar(t, xw, entries)
+	default:
+		writeTestTar(t, gzip.NewWriter(f), entries)
+	}
+
+	return path
+}
+
+func TestGithubRelease_extractArchive(t *testing.T) {
+	entries := []tarEntry{
+		{name: "app-1.0.0/bin/app", body: "app"},
+		{name: "app-1.0.0/README.md", body: "readme"},
+		{name: "app-1.0.0/current", link: "bin/app"},
+	}
+
+	for _, name := range []string{"app.tar.gz", "app.tgz", "app.tar.xz", "app.zip"} {
+		t.Run(name, func(t *testing.T) {
+			dst := t.TempDir()
+
+			err := extractArchive(createTestArchive(t, name, entries), dst, 1)
+			if err != nil {
+				t.Fatalf("extractArchive returned err: %v", err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(dst, "bin", "app"))
+			if err != nil {
+				t.Fatalf("Unable to read extracted file: %v", err)
+			}
+
+			if string(got) != "app" {
+				t.Errorf("extracted file is %q, want %q", got, "app")
+			}
+
+			if _, err := os.Stat(filepath.Join(dst, "README.md")); err != nil {
+				t.Errorf("extractArchive should have extracted README.md: %v", err)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_extractArchive_Unsafe(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{name: "parent traversal", entries: []tarEntry{{name: "../evil", body: "evil"}}},
+		{name: "nested traversal", entries: []tarEntry{{name: "app/../../evil", body: "evil"}}},
+		{name: "absolute link", entries: []tarEntry{{name: "passwd", link: "/etc/passwd"}}},
+		{name: "relative link", entries: []tarEntry{{name: "app/up", link: "../../evil"}}},
+		{name: "chained link", entries: []tarEntry{
+			{name: "S", link: "."},
+			{name: "M", link: "S/.."},
+			{name: "M/evil", body: "evil"},
+		}},
+		{name: "link in parent", entries: []tarEntry{
+			{name: "S", link: "."},
+			{name: "S/up", link: "../evil"},
+		}},
+		{name: "file through link", entries: []tarEntry{
+			{name: "app", link: "."},
+			{name: "app", body: "evil"},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "out")
+
+			err := extractArchive(createTestArchive(t, "evil.tar.gz", test.entries), dst, 0)
+			if !errors.Is(err, ErrorUnsafeArchivePath) {
+				t.Errorf("extractArchive error = %v, wantErr = %v", err, ErrorUnsafeArchivePath)
+			}
+
+			if _, err := os.Lstat(filepath.Join(filepath.Dir(dst), "evil")); err == nil {
+				t.Errorf("extractArchive should not write outside the destination")
+			}
+		})
+	}
+}
+
+func TestGithubRelease_archiveExtension(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "app_linux_amd64.tar.gz", want: ".tar.gz"},
+		{name: "app.TGZ", want: ".tgz"},
+		{name: "app.tar.xz", want: ".tar.xz"},
+		{name: "app.zip", want: ".zip"},
+		{name: "app.tar", want: ""},
+		{name: "checksums.txt", want: ""},
+	}
+
+	for _, test := range tests {
+		got := archiveExtension(test.name)
+
+		if got != test.want {
+			t.Errorf("archiveExtension(%q) is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrorAmbiguousAssetName is returned when an asset name is provided for a pattern matching multiple files.
+	ErrorAmbiguousAssetName = errors.New("asset name provided for pattern matching multiple files")
+
+	// ErrorAssetDirectory is returned in strict mode when a pattern matches a directory.
+	ErrorAssetDirectory = errors.New("asset is a directory")
+
+	// ErrorAssetDuplicateName is returned in strict mode when multiple files produce the same asset name.
+	ErrorAssetDuplicateName = errors.New("duplicate asset name")
+
+	// ErrorAssetEmpty is returned in strict mode when a pattern matches an empty file.
+	ErrorAssetEmpty = errors.New("asset is an empty file")
+
+	// ErrorAssetNoMatch is returned in strict mode when a pattern matches no files.
+	ErrorAssetNoMatch = errors.New("no file matches found")
+)
+
+// _stageDir is the directory renamed assets are linked into before they are sent to gh.
+var _stageDir = filepath.Join(os.TempDir(), "vela-github-release")
+
+// assetOptions represents the configuration for resolving assets.
+type assetOptions struct {
+	// format to archive matched directories in (tar.gz or zip)
+	Archive string
+	// template for the name of archived directories
+	ArchiveName string
+	// list of patterns for files to exclude from the assets
+	Exclude []string
+	// directory to stage renamed assets in (default: a directory in the temp directory)
+	StageDir string
+	// fail on unmatched patterns, directories, empty files and duplicate asset names
+	Strict bool
+	// tag name of the release
+	Tag string
+}
+
+// asset represents a file to attach to a release.
+type asset struct {
+	// format the directory at the path is archived in
+	Archive string
+	// display label of the asset
+	Label string
+	// name of the asset on the release
+	Name string
+	// path to the file on disk
+	Path string
+
+	// directory the asset is staged in when it is renamed
+	stage string
+}
+
+// renamed returns true if the asset name differs from the file
+// name or the asset is an archive of a directory.
+func (a asset) renamed() bool {
+	return len(a.Archive) > 0 || a.Name != filepath.Base(a.Path)
+}
+
+// File returns the path to the file sent to gh for the asset,
+// which is in the stage directory for renamed assets.
+func (a asset) File() string {
+	if !a.renamed() {
+		return a.Path
+	}
+
+	if len(a.stage) == 0 {
+		return filepath.Join(_stageDir, a.Name)
+	}
+
+	return filepath.Join(a.stage, a.Name)
+}
+
+// Arg returns the gh argument for the asset in the path#label format.
+func (a asset) Arg() string {
+	path := a.File()
+
+	if len(a.Label) > 0 {
+		return fmt.Sprintf("%s#%s", path, a.Label)
+	}
+
+	return path
+}
+
+// parseAsset is a helper function to split an asset in the
+// pattern[=name][#label] format into its components.
+func parseAsset(spec string) (string, string, string) {
+	pattern, label, _ := strings.Cut(spec, "#")
+
+	// only treat "=" as a rename when the name is not a path
+	i := strings.LastIndex(pattern, "=")
+	if i < 0 || strings.ContainsAny(pattern[i+1:], `/\`) {
+		return pattern, "", label
+	}
+
+	return pattern[:i], pattern[i+1:], label
+}
+
+// excluded is a helper function to check if the provided file
+// matches any of the exclude patterns. Patterns without a path
+// separator are matched against the file name.
+func excluded(file string, exclude []string) bool {
+	for _, pattern := range exclude {
+		name := filepath.ToSlash(file)
+
+		if !strings.Contains(pattern, "/") {
+			name = filepath.Base(file)
+		}
+
+		ok, err := doublestar.Match(pattern, name)
+		if err != nil {
+			logrus.Warnf("bad exclude pattern: %v", err)
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveFiles is a helper function to expand the provided glob
+// pattern, supporting "**", into sorted lists of file and directory paths.
+func resolveFiles(pattern string, exclude []string) ([]string, []string) {
+	matches, err := doublestar.FilepathGlob(pattern)
+	if err != nil {
+		logrus.Warnf("bad file pattern: %v", err)
+	}
+
+	// sort the matches for a deterministic order
+	sort.Strings(matches)
+
+	var files, dirs []string
+
+	for _, match := range matches {
+		if excluded(match, exclude) {
+			logrus.Debugf("excluding file %s", match)
+
+			continue
+		}
+
+		// gh is unable to upload directories
+		info, err := os.Stat(match)
+		if err == nil && info.IsDir() {
+			logrus.Debugf("skipping directory %s", match)
+
+			dirs = append(dirs, match)
+
+			continue
+		}
+
+		files = append(files, match)
+	}
+
+	if len(files) == 0 {
+		logrus.Warnf("no file matches found for %s", pattern)
+	}
+
+	return files, dirs
+}
+
+// resolveAssets is a helper function to expand the provided
+// assets in the pattern[=name][#label] format into assets,
+// skipping files matching the exclude patterns and archiving
+// directories when an archive format is provided. In strict mode,
+// unmatched patterns, directories, empty files and duplicate
+// asset names are reported together as errors.
+func resolveAssets(specs []string, opts assetOptions) ([]asset, error) {
+	var (
+		assets []asset
+		errs   []error
+	)
+
+	// track files already matched by an earlier pattern
+	seen := make(map[string]bool)
+
+	// track the file producing each asset name
+	names := make(map[string]string)
+
+	for _, spec := range specs {
+		pattern, name, label := parseAsset(spec)
+
+		files, dirs := resolveFiles(pattern, opts.Exclude)
+
+		var archives []asset
+
+		// check if directories should be archived
+		if len(opts.Archive) > 0 {
+			for _, dir := range dirs {
+				n, err := archiveName(opts.ArchiveName, dir, opts.Archive, opts.Tag)
+				if err != nil {
+					errs = append(errs, err)
+
+					continue
+				}
+
+				archives = append(archives, asset{Archive: opts.Archive, Label: label, Name: n, Path: dir})
+			}
+
+			dirs = nil
+		}
+
+		// verify a name is only provided for a single file
+		if len(name) > 0 && len(files)+len(archives) > 1 {
+			errs = append(errs, fmt.Errorf("%w: %s matches %d files", ErrorAmbiguousAssetName, spec, len(files)+len(archives)))
+
+			continue
+		}
+
+		for _, file := range files {
+			archives = append(archives, asset{Label: label, Path: file})
+		}
+
+		if opts.Strict {
+			for _, dir := range dirs {
+				errs = append(errs, fmt.Errorf("%w: %s matched by %s", ErrorAssetDirectory, dir, pattern))
+			}
+
+			if len(archives) == 0 && len(dirs) == 0 {
+				errs = append(errs, fmt.Errorf("%w: %s", ErrorAssetNoMatch, pattern))
+			}
+		}
+
+		for _, a := range archives {
+			if seen[a.Path] {
+				continue
+			}
+
+			seen[a.Path] = true
+
+			if len(name) > 0 {
+				a.Name = name
+			}
+
+			if len(a.Name) == 0 {
+				a.Name = filepath.Base(a.Path)
+			}
+
+			a.stage = opts.StageDir
+
+			if opts.Strict {
+				info, err := os.Stat(a.Path)
+				if err == nil && len(a.Archive) == 0 && info.Size() == 0 {
+					errs = append(errs, fmt.Errorf("%w: %s", ErrorAssetEmpty, a.Path))
+				}
+
+				if other, ok := names[a.Name]; ok {
+					errs = append(errs, fmt.Errorf("%w: %s is produced by %s and %s", ErrorAssetDuplicateName, a.Name, other, a.Path))
+				}
+
+				names[a.Name] = a.Path
+			}
+
+			assets = append(assets, a)
+		}
+	}
+
+	return assets, errors.Join(errs...)
+}
+
+// assetArgs is a helper function to return the gh arguments for the provided assets.
+func assetArgs(assets []asset) []string {
+	args := make([]string, 0, len(assets))
+
+	for _, a := range assets {
+		args = append(args, a.Arg())
+	}
+
+	return args
+}
+
+// stageAssets is a helper function to link renamed assets and
+// archive directories into the stage directory under their asset name.
+func stageAssets(assets []asset) error {
+	for _, a := range assets {
+		if !a.renamed() {
+			continue
+		}
+
+		path, err := filepath.Abs(a.Path)
+		if err != nil {
+			return err
+		}
+
+		link := a.File()
+
+		err = os.MkdirAll(filepath.Dir(link), 0755)
+		if err != nil {
+			return err
+		}
+
+		// remove a file left behind by a previous run
+		err = os.Remove(link)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		// check if the directory should be archived
+		if len(a.Archive) > 0 {
+			err = archiveDir(path, link, a.Archive)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		logrus.Debugf("staging %s as asset %s", a.Path, a.Name)
+
+		err = os.Symlink(path, link)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
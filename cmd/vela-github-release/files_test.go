@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGithubRelease_parseAsset(t *testing.T) {
+	tests := []struct {
+		spec    string
+		pattern string
+		name    string
+		label   string
+	}{
+		{spec: "dist/app", pattern: "dist/app"},
+		{spec: "dist/app#Linux x86_64 binary", pattern: "dist/app", label: "Linux x86_64 binary"},
+		{spec: "dist/app=app_v1.2.3_linux_amd64", pattern: "dist/app", name: "app_v1.2.3_linux_amd64"},
+		{spec: "dist/app=app_linux#Linux binary", pattern: "dist/app", name: "app_linux", label: "Linux binary"},
+		{spec: "dist/a=b/app", pattern: "dist/a=b/app"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			pattern, name, label := parseAsset(test.spec)
+
+			if pattern != test.pattern || name != test.name || label != test.label {
+				t.Errorf("parseAsset is (%v, %v, %v), want (%v, %v, %v)", pattern, name, label, test.pattern, test.name, test.label)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_resolveAssets(t *testing.T) {
+	got, err := resolveAssets([]string{
+		"testdata/file=app_linux_amd64#Linux binary",
+		"testdata/*.txt#Text",
+		"testdata/missing",
+	}, assetOptions{})
+	if err != nil {
+		t.Errorf("resolveAssets returned err: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(_stageDir, "app_linux_amd64") + "#Linux binary",
+		"testdata/test1.txt#Text",
+		"testdata/test2.txt#Text",
+	}
+
+	args := assetArgs(got)
+
+	if len(args) != len(want) {
+		t.Fatalf("assetArgs is %v, want %v", args, want)
+	}
+
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("assetArgs[%d] is %v, want %v", i, args[i], want[i])
+		}
+	}
+}
+
+func TestGithubRelease_resolveAssets_Recursive(t *testing.T) {
+	got, err := resolveAssets([]string{
+		"testdata/nested/deep/test4.txt",
+		"testdata/**/*",
+	}, assetOptions{Exclude: []string{"*.sig", "testdata/test2.txt"}})
+	if err != nil {
+		t.Errorf("resolveAssets returned err: %v", err)
+	}
+
+	want := []string{
+		"testdata/nested/deep/test4.txt",
+		"testdata/file",
+		"testdata/nested/test3.txt",
+		"testdata/test1.txt",
+	}
+
+	args := assetArgs(got)
+
+	if len(args) != len(want) {
+		t.Fatalf("assetArgs is %v, want %v", args, want)
+	}
+
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("assetArgs[%d] is %v, want %v", i, args[i], want[i])
+		}
+	}
+}
+
+func TestGithubRelease_resolveAssets_Strict(t *testing.T) {
+	// setup filesystem
+	dir := t.TempDir()
+
+	for _, file := range []string{"linux/app", "darwin/app", "empty"} {
+		path := filepath.Join(dir, file)
+
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("Unable to create directory: %v", err)
+		}
+
+		content := []byte("app")
+		if file == "empty" {
+			content = nil
+		}
+
+		err = os.WriteFile(path, content, 0600)
+		if err != nil {
+			t.Fatalf("Unable to write file %s: %v", path, err)
+		}
+	}
+
+	_, err := resolveAssets([]string{
+		filepath.Join(dir, "*", "app"),
+		filepath.Join(dir, "*"),
+		filepath.Join(dir, "missing"),
+	}, assetOptions{Strict: true})
+
+	for _, want := range []error{ErrorAssetDuplicateName, ErrorAssetDirectory, ErrorAssetEmpty, ErrorAssetNoMatch} {
+		if !errors.Is(err, want) {
+			t.Errorf("resolveAssets error = %v, want it to include %v", err, want)
+		}
+	}
+}
+
+func TestGithubRelease_resolveAssets_Archive(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	got, err := resolveAssets([]string{
+		"testdata/nest*#Nested",
+		"testdata/test1.txt",
+	}, assetOptions{
+		Archive:     archiveZip,
+		ArchiveName: "{{ .Name }}_{{ .Tag }}.{{ .Format }}",
+		StageDir:    dir,
+		Strict:      true,
+		Tag:         "v1.0.0",
+	})
+	if err != nil {
+		t.Errorf("resolveAssets returned err: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "nested_v1.0.0.zip") + "#Nested",
+		"testdata/test1.txt",
+	}
+
+	args := assetArgs(got)
+
+	if len(args) != len(want) {
+		t.Fatalf("assetArgs is %v, want %v", args, want)
+	}
+
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("assetArgs[%d] is %v, want %v", i, args[i], want[i])
+		}
+	}
+}
+
+func TestGithubRelease_resolveAssets_Ambiguous(t *testing.T) {
+	_, err := resolveAssets([]string{"testdata/*.txt=notes.txt"}, assetOptions{})
+	if !errors.Is(err, ErrorAmbiguousAssetName) {
+		t.Errorf("resolveAssets error = %v, wantErr = %v", err, ErrorAmbiguousAssetName)
+	}
+}
+
+func TestGithubRelease_stageAssets(t *testing.T) {
+	// setup types
+	dir := t.TempDir()
+
+	assets := []asset{
+		{Name: "staged_test1.txt", Path: "testdata/test1.txt", stage: dir},
+		{Name: "test2.txt", Path: "testdata/test2.txt", stage: dir},
+	}
+
+	err := stageAssets(assets)
+	if err != nil {
+		t.Errorf("stageAssets returned err: %v", err)
+	}
+
+	link := filepath.Join(dir, "staged_test1.txt")
+
+	got, err := os.ReadFile(link)
+	if err != nil {
+		t.Errorf("Unable to read staged asset: %v", err)
+	}
+
+	if string(got) != "test1\n" {
+		t.Errorf("staged asset is %q, want %q", got, "test1\n")
+	}
+
+	if _, err := os.Lstat(filepath.Join(dir, "test2.txt")); err == nil {
+		t.Errorf("stageAssets should not stage assets that are not renamed")
+	}
+}
@@ -21,6 +21,38 @@ func flags() []cli.Flag {
 // coreFlags returns the core application flags.
 func coreFlags() []cli.Flag {
 	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "archive",
+			Usage: "archive directories matched by files before upload - options: (tar.gz|zip)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_ARCHIVE"),
+				cli.EnvVar("GITHUB_RELEASE_ARCHIVE"),
+				cli.File("/vela/parameters/github-release/archive"),
+				cli.File("/vela/secrets/github-release/archive"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "archive_name",
+			Value: _archiveName,
+			Usage: "template for the name of archived directories",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_ARCHIVE_NAME"),
+				cli.EnvVar("GITHUB_RELEASE_ARCHIVE_NAME"),
+				cli.File("/vela/parameters/github-release/archive_name"),
+				cli.File("/vela/secrets/github-release/archive_name"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "stage_dir",
+			Value: _stageDir,
+			Usage: "directory to stage renamed assets and archived directories in before upload",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_STAGE_DIR"),
+				cli.EnvVar("GITHUB_RELEASE_STAGE_DIR"),
+				cli.File("/vela/parameters/github-release/stage_dir"),
+				cli.File("/vela/secrets/github-release/stage_dir"),
+			),
+		},
 		&cli.StringSliceFlag{
 			Name:  "files",
 			Usage: "files name used for action",
@@ -31,6 +63,16 @@ func coreFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/files"),
 			),
 		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "file patterns to exclude from files used for action",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_EXCLUDE"),
+				cli.EnvVar("GITHUB_RELEASE_EXCLUDE"),
+				cli.File("/vela/parameters/github-release/exclude"),
+				cli.File("/vela/secrets/github-release/exclude"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "log.level",
 			Value: "info",
@@ -43,9 +85,19 @@ func coreFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/log_level"),
 			),
 		},
+		&cli.BoolFlag{
+			Name:  "strict",
+			Usage: "fail on unmatched patterns, directories, empty files and duplicate asset names in files",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_STRICT"),
+				cli.EnvVar("GITHUB_RELEASE_STRICT"),
+				cli.File("/vela/parameters/github-release/strict"),
+				cli.File("/vela/secrets/github-release/strict"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "tag",
-			Usage: "tag name used for action",
+			Usage: "tag name used for action - use auto to compute the next version on create",
 			Sources: cli.NewValueSourceChain(
 				cli.EnvVar("PARAMETER_TAG"),
 				cli.EnvVar("GITHUB_RELEASE_TAG"),
@@ -53,9 +105,49 @@ func coreFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/tag"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "tag.pattern",
+			Usage: "regular expression the tag must match",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_TAG_PATTERN"),
+				cli.EnvVar("GITHUB_RELEASE_TAG_PATTERN"),
+				cli.File("/vela/parameters/github-release/tag_pattern"),
+				cli.File("/vela/secrets/github-release/tag_pattern"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "tag.prefix",
+			Usage: "required prefix for the tag",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_TAG_PREFIX"),
+				cli.EnvVar("GITHUB_RELEASE_TAG_PREFIX"),
+				cli.File("/vela/parameters/github-release/tag_prefix"),
+				cli.File("/vela/secrets/github-release/tag_prefix"),
+			),
+		},
+		&cli.StringSliceFlag{
+			Name:  "tag.prerelease",
+			Usage: "allowed prerelease identifiers for the tag",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_TAG_PRERELEASE"),
+				cli.EnvVar("GITHUB_RELEASE_TAG_PRERELEASE"),
+				cli.File("/vela/parameters/github-release/tag_prerelease"),
+				cli.File("/vela/secrets/github-release/tag_prerelease"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "tag.semver",
+			Usage: "require the tag to be a valid semantic version",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_TAG_SEMVER"),
+				cli.EnvVar("GITHUB_RELEASE_TAG_SEMVER"),
+				cli.File("/vela/parameters/github-release/tag_semver"),
+				cli.File("/vela/secrets/github-release/tag_semver"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "gh.version",
-			Usage: "set gh version for plugin",
+			Usage: "set gh version or semver constraint for plugin",
 			Sources: cli.NewValueSourceChain(
 				cli.EnvVar("PARAMETER_VERSION"),
 				cli.EnvVar("VELA_GH_VERSION"),
@@ -64,6 +156,68 @@ func coreFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/version"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "gh.url",
+			Usage: "URL template to download gh from - placeholders: ({version}|{os}|{arch})",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_URL"),
+				cli.EnvVar("GITHUB_RELEASE_GH_URL"),
+				cli.File("/vela/parameters/github-release/gh/url"),
+				cli.File("/vela/secrets/github-release/gh/url"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "gh.tarball",
+			Usage: "path to a local gh tarball to install from",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_TARBALL"),
+				cli.EnvVar("GITHUB_RELEASE_GH_TARBALL"),
+				cli.File("/vela/parameters/github-release/gh/tarball"),
+				cli.File("/vela/secrets/github-release/gh/tarball"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "gh.checksums",
+			Usage: "URL template of the checksums file to verify the gh download against - placeholders: ({version}|{os}|{arch})",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_CHECKSUMS"),
+				cli.EnvVar("GITHUB_RELEASE_GH_CHECKSUMS"),
+				cli.File("/vela/parameters/github-release/gh/checksums"),
+				cli.File("/vela/secrets/github-release/gh/checksums"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "gh.cache_dir",
+			Usage: "directory to cache custom gh versions in across builds",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_CACHE_DIR"),
+				cli.EnvVar("GITHUB_RELEASE_GH_CACHE_DIR"),
+				cli.File("/vela/parameters/github-release/gh/cache_dir"),
+				cli.File("/vela/secrets/github-release/gh/cache_dir"),
+			),
+		},
+		&cli.IntFlag{
+			Name:  "gh.cache_limit",
+			Value: 5,
+			Usage: "maximum number of gh versions to keep in the cache",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_CACHE_LIMIT"),
+				cli.EnvVar("GITHUB_RELEASE_GH_CACHE_LIMIT"),
+				cli.File("/vela/parameters/github-release/gh/cache_limit"),
+				cli.File("/vela/secrets/github-release/gh/cache_limit"),
+			),
+		},
+		&cli.IntFlag{
+			Name:  "gh.cache_size",
+			Value: 512,
+			Usage: "maximum size in MiB of the gh versions to keep in the cache",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GH_CACHE_SIZE"),
+				cli.EnvVar("GITHUB_RELEASE_GH_CACHE_SIZE"),
+				cli.File("/vela/parameters/github-release/gh/cache_size"),
+				cli.File("/vela/secrets/github-release/gh/cache_size"),
+			),
+		},
 	}
 }
 
@@ -111,7 +265,98 @@ func githubConfigFlags() []cli.Flag {
 // releaseOperationFlags returns flags for create, delete, and view operations.
 func releaseOperationFlags() []cli.Flag {
 	return []cli.Flag{
+		// Copy Flags
+		&cli.StringFlag{
+			Name:  "copy.destination_tag",
+			Usage: "tag name of the release in the destination repository (default: tag)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_DESTINATION_TAG"),
+				cli.EnvVar("COPY_DESTINATION_TAG"),
+				cli.File("/vela/parameters/github-release/copy/destination_tag"),
+				cli.File("/vela/secrets/github-release/copy/destination_tag"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "copy.repo",
+			Usage: "destination repository in the [HOST/]OWNER/REPO format (default: current repository)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_REPO"),
+				cli.EnvVar("COPY_REPO"),
+				cli.File("/vela/parameters/github-release/copy/repo"),
+				cli.File("/vela/secrets/github-release/copy/repo"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "copy.source_hostname",
+			Usage: "hostname of the source repository",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_HOSTNAME"),
+				cli.EnvVar("COPY_SOURCE_HOSTNAME"),
+				cli.File("/vela/parameters/github-release/copy/source_hostname"),
+				cli.File("/vela/secrets/github-release/copy/source_hostname"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "copy.source_repo",
+			Usage: "source repository in the OWNER/REPO format",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_REPO"),
+				cli.EnvVar("COPY_SOURCE_REPO"),
+				cli.File("/vela/parameters/github-release/copy/source_repo"),
+				cli.File("/vela/secrets/github-release/copy/source_repo"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "copy.source_token",
+			Usage: "token to authenticate to the source hostname",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_TOKEN"),
+				cli.EnvVar("COPY_SOURCE_TOKEN"),
+				cli.File("/vela/parameters/github-release/copy/source_token"),
+				cli.File("/vela/secrets/github-release/copy/source_token"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "copy.target",
+			Usage: "target branch or commit SHA for the destination tag",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_TARGET"),
+				cli.EnvVar("COPY_TARGET"),
+				cli.File("/vela/parameters/github-release/copy/target"),
+				cli.File("/vela/secrets/github-release/copy/target"),
+			),
+		},
 		// Create Flags
+		&cli.BoolFlag{
+			Name:  "create.changelog",
+			Usage: "create release notes from the git history since the previous tag",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_CHANGELOG"),
+				cli.EnvVar("CREATE_CHANGELOG"),
+				cli.File("/vela/parameters/github-release/create/changelog"),
+				cli.File("/vela/secrets/github-release/create/changelog"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "create.changelog_from",
+			Usage: "previous tag to start the changelog from",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_CHANGELOG_FROM"),
+				cli.EnvVar("CREATE_CHANGELOG_FROM"),
+				cli.File("/vela/parameters/github-release/create/changelog_from"),
+				cli.File("/vela/secrets/github-release/create/changelog_from"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "create.discussion_category",
+			Usage: "start a discussion in the given category for the release",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_DISCUSSION_CATEGORY"),
+				cli.EnvVar("CREATE_DISCUSSION_CATEGORY"),
+				cli.File("/vela/parameters/github-release/create/discussion_category"),
+				cli.File("/vela/secrets/github-release/create/discussion_category"),
+			),
+		},
 		&cli.BoolFlag{
 			Name:  "create.draft",
 			Usage: "save the release as a draft instead of publishing it",
@@ -122,6 +367,26 @@ func releaseOperationFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/create/draft"),
 			),
 		},
+		&cli.BoolFlag{
+			Name:  "create.generate_notes",
+			Usage: "automatically generate title and notes for the release",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_GENERATE_NOTES"),
+				cli.EnvVar("CREATE_GENERATE_NOTES"),
+				cli.File("/vela/parameters/github-release/create/generate_notes"),
+				cli.File("/vela/secrets/github-release/create/generate_notes"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "create.latest",
+			Usage: "mark the release as latest - options: (true|false|auto)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_LATEST"),
+				cli.EnvVar("CREATE_LATEST"),
+				cli.File("/vela/parameters/github-release/create/latest"),
+				cli.File("/vela/secrets/github-release/create/latest"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "create.notes",
 			Usage: "create release notes",
@@ -142,9 +407,20 @@ func releaseOperationFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/create/notes_file"),
 			),
 		},
-		&cli.BoolFlag{
+		&cli.StringFlag{
+			Name:  "create.notes_start_tag",
+			Usage: "tag to use as the starting point for generating release notes",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_NOTES_START_TAG"),
+				cli.EnvVar("CREATE_NOTES_START_TAG"),
+				cli.File("/vela/parameters/github-release/create/notes_start_tag"),
+				cli.File("/vela/secrets/github-release/create/notes_start_tag"),
+			),
+		},
+		&cli.StringFlag{
 			Name:  "create.prerelease",
-			Usage: "mark the release as a prerelease",
+			Value: "false",
+			Usage: "mark the release as a prerelease - options: (true|false|auto)",
 			Sources: cli.NewValueSourceChain(
 				cli.EnvVar("PARAMETER_PRERELEASE"),
 				cli.EnvVar("CREATE_PRERELEASE"),
@@ -152,6 +428,26 @@ func releaseOperationFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/create/prerelease"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "create.prerelease_id",
+			Usage: "prerelease identifier to use when computing the next tag",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_PRERELEASE_ID"),
+				cli.EnvVar("CREATE_PRERELEASE_ID"),
+				cli.File("/vela/parameters/github-release/create/prerelease_id"),
+				cli.File("/vela/secrets/github-release/create/prerelease_id"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "create.stable_default_branch",
+			Usage: "refuse to create a stable release from a target other than the default branch",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_STABLE_DEFAULT_BRANCH"),
+				cli.EnvVar("CREATE_STABLE_DEFAULT_BRANCH"),
+				cli.File("/vela/parameters/github-release/create/stable_default_branch"),
+				cli.File("/vela/secrets/github-release/create/stable_default_branch"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "create.target",
 			Value: "main",
@@ -173,6 +469,16 @@ func releaseOperationFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/create/title"),
 			),
 		},
+		&cli.BoolFlag{
+			Name:  "create.verify_tag",
+			Usage: "require the tag to exist in the remote repository and point at the target commit",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_VERIFY_TAG"),
+				cli.EnvVar("CREATE_VERIFY_TAG"),
+				cli.File("/vela/parameters/github-release/create/verify_tag"),
+				cli.File("/vela/secrets/github-release/create/verify_tag"),
+			),
+		},
 		// Delete Flags
 		&cli.BoolFlag{
 			Name:  "delete.yes",
@@ -185,6 +491,16 @@ func releaseOperationFlags() []cli.Flag {
 			),
 			//  TODO: should this be set with default to bypass the prompt? : Value: true,
 		},
+		&cli.BoolFlag{
+			Name:  "delete.cleanup_tag",
+			Usage: "delete the tag in addition to the release",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_CLEANUP_TAG"),
+				cli.EnvVar("DELETE_CLEANUP_TAG"),
+				cli.File("/vela/parameters/github-release/delete/cleanup_tag"),
+				cli.File("/vela/secrets/github-release/delete/cleanup_tag"),
+			),
+		},
 		// View Flags
 		&cli.BoolFlag{
 			Name:  "view.web",
@@ -199,10 +515,20 @@ func releaseOperationFlags() []cli.Flag {
 	}
 }
 
-// utilityFlags returns flags for download, list, and upload operations.
+// utilityFlags returns flags for download, list, mirror, prune, and upload operations.
 func utilityFlags() []cli.Flag {
 	return []cli.Flag{
 		// Download Flags
+		&cli.BoolFlag{
+			Name:  "download.delete_archive",
+			Usage: "delete archives after they are extracted",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_DELETE_ARCHIVE"),
+				cli.EnvVar("DOWNLOAD_DELETE_ARCHIVE"),
+				cli.File("/vela/parameters/github-release/download/delete_archive"),
+				cli.File("/vela/secrets/github-release/download/delete_archive"),
+			),
+		},
 		&cli.StringFlag{
 			Name:  "download.dir",
 			Value: ".",
@@ -214,6 +540,37 @@ func utilityFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/download/dir"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "download.existing",
+			Value: "error",
+			Usage: "policy for files that already exist in the download directory - options: (error|skip|overwrite|skip-if-same-size|skip-if-same-digest)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_EXISTING"),
+				cli.EnvVar("DOWNLOAD_EXISTING"),
+				cli.File("/vela/parameters/github-release/download/existing"),
+				cli.File("/vela/secrets/github-release/download/existing"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "download.extract",
+			Usage: "extract downloaded .tar.gz, .tgz, .tar.xz and .zip assets",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_EXTRACT"),
+				cli.EnvVar("DOWNLOAD_EXTRACT"),
+				cli.File("/vela/parameters/github-release/download/extract"),
+				cli.File("/vela/secrets/github-release/download/extract"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "download.extract_dir",
+			Usage: "directory to extract all archives into (default: a subdirectory of the download directory per archive)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_EXTRACT_DIR"),
+				cli.EnvVar("DOWNLOAD_EXTRACT_DIR"),
+				cli.File("/vela/parameters/github-release/download/extract_dir"),
+				cli.File("/vela/secrets/github-release/download/extract_dir"),
+			),
+		},
 		&cli.StringSliceFlag{
 			Name:  "download.patterns",
 			Usage: "download only assets that match glob patterns",
@@ -224,6 +581,46 @@ func utilityFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/download/patterns"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "download.releases",
+			Usage: "JSON list of releases to download, each with a tag or semver constraint and optional patterns, dir and repo",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_RELEASES"),
+				cli.EnvVar("DOWNLOAD_RELEASES"),
+				cli.File("/vela/parameters/github-release/download/releases"),
+				cli.File("/vela/secrets/github-release/download/releases"),
+			),
+		},
+		&cli.StringSliceFlag{
+			Name:  "download.source_archives",
+			Usage: "formats to download the source archive of the release in - options: (tar.gz|zip)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_ARCHIVES"),
+				cli.EnvVar("DOWNLOAD_SOURCE_ARCHIVES"),
+				cli.File("/vela/parameters/github-release/download/source_archives"),
+				cli.File("/vela/secrets/github-release/download/source_archives"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "download.source_only",
+			Usage: "download only the source archives instead of the assets",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_ONLY"),
+				cli.EnvVar("DOWNLOAD_SOURCE_ONLY"),
+				cli.File("/vela/parameters/github-release/download/source_only"),
+				cli.File("/vela/secrets/github-release/download/source_only"),
+			),
+		},
+		&cli.IntFlag{
+			Name:  "download.strip_components",
+			Usage: "number of leading path components to remove from extracted entries",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_STRIP_COMPONENTS"),
+				cli.EnvVar("DOWNLOAD_STRIP_COMPONENTS"),
+				cli.File("/vela/parameters/github-release/download/strip_components"),
+				cli.File("/vela/secrets/github-release/download/strip_components"),
+			),
+		},
 		// List Flags
 		&cli.IntFlag{
 			Name:  "list.limit",
@@ -236,6 +633,140 @@ func utilityFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/list/limit"),
 			),
 		},
+		// Mirror Flags
+		&cli.StringFlag{
+			Name:  "mirror.pattern",
+			Usage: "regular expression the tags of mirrored releases must match",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_PATTERN"),
+				cli.EnvVar("MIRROR_PATTERN"),
+				cli.File("/vela/parameters/github-release/mirror/pattern"),
+				cli.File("/vela/secrets/github-release/mirror/pattern"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "mirror.range",
+			Usage: "semantic version range the tags of mirrored releases must satisfy",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_RANGE"),
+				cli.EnvVar("MIRROR_RANGE"),
+				cli.File("/vela/parameters/github-release/mirror/range"),
+				cli.File("/vela/secrets/github-release/mirror/range"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "mirror.repo",
+			Usage: "destination repository in the OWNER/REPO format (default: current repository)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_REPO"),
+				cli.EnvVar("MIRROR_REPO"),
+				cli.File("/vela/parameters/github-release/mirror/repo"),
+				cli.File("/vela/secrets/github-release/mirror/repo"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "mirror.source_hostname",
+			Value: "github.com",
+			Usage: "hostname of the source repository",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_HOSTNAME"),
+				cli.EnvVar("MIRROR_SOURCE_HOSTNAME"),
+				cli.File("/vela/parameters/github-release/mirror/source_hostname"),
+				cli.File("/vela/secrets/github-release/mirror/source_hostname"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "mirror.source_repo",
+			Usage: "source repository in the OWNER/REPO format",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_REPO"),
+				cli.EnvVar("MIRROR_SOURCE_REPO"),
+				cli.File("/vela/parameters/github-release/mirror/source_repo"),
+				cli.File("/vela/secrets/github-release/mirror/source_repo"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "mirror.source_token",
+			Usage: "token to authenticate to the source hostname",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SOURCE_TOKEN"),
+				cli.EnvVar("MIRROR_SOURCE_TOKEN"),
+				cli.File("/vela/parameters/github-release/mirror/source_token"),
+				cli.File("/vela/secrets/github-release/mirror/source_token"),
+			),
+		},
+		// Prune Flags
+		&cli.BoolFlag{
+			Name:  "prune.cleanup_tag",
+			Usage: "delete the tags of the pruned releases",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_CLEANUP_TAG"),
+				cli.EnvVar("PRUNE_CLEANUP_TAG"),
+				cli.File("/vela/parameters/github-release/prune/cleanup_tag"),
+				cli.File("/vela/secrets/github-release/prune/cleanup_tag"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "prune.dry_run",
+			Usage: "list the releases that would be deleted without deleting them",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_DRY_RUN"),
+				cli.EnvVar("PRUNE_DRY_RUN"),
+				cli.File("/vela/parameters/github-release/prune/dry_run"),
+				cli.File("/vela/secrets/github-release/prune/dry_run"),
+			),
+		},
+		&cli.IntFlag{
+			Name:  "prune.keep",
+			Usage: "number of the newest selected releases to keep",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_KEEP"),
+				cli.EnvVar("PRUNE_KEEP"),
+				cli.File("/vela/parameters/github-release/prune/keep"),
+				cli.File("/vela/secrets/github-release/prune/keep"),
+			),
+		},
+		&cli.DurationFlag{
+			Name:  "prune.older_than",
+			Usage: "only delete selected releases created longer ago than the duration (e.g. 720h)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_OLDER_THAN"),
+				cli.EnvVar("PRUNE_OLDER_THAN"),
+				cli.File("/vela/parameters/github-release/prune/older_than"),
+				cli.File("/vela/secrets/github-release/prune/older_than"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "prune.pattern",
+			Usage: "regular expression the tags of selected releases must match",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_PATTERN"),
+				cli.EnvVar("PRUNE_PATTERN"),
+				cli.File("/vela/parameters/github-release/prune/pattern"),
+				cli.File("/vela/secrets/github-release/prune/pattern"),
+			),
+		},
+		&cli.StringFlag{
+			Name:  "prune.sort",
+			Value: "semver",
+			Usage: "order used to determine the newest releases - options: (semver|date)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SORT"),
+				cli.EnvVar("PRUNE_SORT"),
+				cli.File("/vela/parameters/github-release/prune/sort"),
+				cli.File("/vela/secrets/github-release/prune/sort"),
+			),
+		},
+		&cli.StringSliceFlag{
+			Name:  "prune.status",
+			Usage: "release statuses to select - options: (draft|prerelease|release)",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_STATUS"),
+				cli.EnvVar("PRUNE_STATUS"),
+				cli.File("/vela/parameters/github-release/prune/status"),
+				cli.File("/vela/secrets/github-release/prune/status"),
+			),
+		},
 		// Upload Flags
 		&cli.BoolFlag{
 			Name:  "upload.clobber",
@@ -247,5 +778,25 @@ func utilityFlags() []cli.Flag {
 				cli.File("/vela/secrets/github-release/upload/clobber"),
 			),
 		},
+		&cli.StringFlag{
+			Name:  "upload.checksum_manifest",
+			Usage: "name of a release asset listing sha256 digests to compare assets without a recorded digest",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_CHECKSUM_MANIFEST"),
+				cli.EnvVar("UPLOAD_CHECKSUM_MANIFEST"),
+				cli.File("/vela/parameters/github-release/upload/checksum_manifest"),
+				cli.File("/vela/secrets/github-release/upload/checksum_manifest"),
+			),
+		},
+		&cli.BoolFlag{
+			Name:  "upload.skip_unchanged",
+			Usage: "only upload assets that are new or differ from the existing assets",
+			Sources: cli.NewValueSourceChain(
+				cli.EnvVar("PARAMETER_SKIP_UNCHANGED"),
+				cli.EnvVar("UPLOAD_SKIP_UNCHANGED"),
+				cli.File("/vela/parameters/github-release/upload/skip_unchanged"),
+				cli.File("/vela/secrets/github-release/upload/skip_unchanged"),
+			),
+		},
 	}
 }
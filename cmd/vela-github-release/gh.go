@@ -4,50 +4,195 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	getter "github.com/hashicorp/go-getter/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 )
 
 const (
-	_gh       = "/bin/gh"
-	_ghTmp    = "/bin/download"
-	_download = "https://github.com/cli/cli/releases/download/v%s/gh_%s_%s_%s.tar.gz//gh_%s_%s_%s/bin"
+	_gh        = "/bin/gh"
+	_ghTmp     = "/bin/download"
+	_download  = "https://github.com/cli/cli/releases/download/v{version}/gh_{version}_{os}_{arch}.tar.gz"
+	_archive   = "gh_{version}_{os}_{arch}/bin"
+	_checksums = "https://github.com/cli/cli/releases/download/v{version}/gh_{version}_checksums.txt"
 )
 
-// install downloads a custom version of the gh cli.
-func install(ctx context.Context, customVer, defaultVer string) error {
-	logrus.Infof("custom gh version requested: %s", customVer)
+var (
+	// ErrorInvalidGHVersion is returned when the gh version is not a valid version or constraint.
+	ErrorInvalidGHVersion = errors.New("invalid gh version provided")
+
+	// ErrorUnsatisfiedGHVersion is returned when the bundled gh version does not satisfy the gh version constraint.
+	ErrorUnsatisfiedGHVersion = errors.New("bundled gh version does not satisfy constraint")
+)
+
+// GH represents the plugin configuration for installing a custom gh version.
+type GH struct {
+	// directory to cache verified gh binaries in
+	CacheDir string
+	// maximum number of gh versions to keep in the cache
+	CacheLimit int
+	// maximum size in MiB of the gh versions kept in the cache
+	CacheSize int
+	// URL template of the checksums file to verify the gh download against
+	Checksums string
+	// version of gh bundled with the plugin image
+	Default string
+	// path to a local gh tarball to install from
+	Tarball string
+	// URL template to download the gh tarball from
+	URL string
+	// custom version or version constraint of gh to install
+	Version string
+}
+
+// version is a helper function to return the normalized
+// custom version, without a leading "v", when the custom
+// version is an exact version rather than a constraint.
+func (g *GH) version() (string, bool) {
+	v, err := semver.NewVersion(g.Version)
+	if err != nil {
+		return g.Version, false
+	}
+
+	return v.String(), true
+}
+
+// satisfied is a helper function to check if the bundled
+// gh version already satisfies the custom version.
+func (g *GH) satisfied() (bool, error) {
+	// parse the bundled version of gh
+	def, err := semver.NewVersion(g.Default)
+	if err != nil {
+		logrus.Debugf("unable to parse default gh version %s: %v", g.Default, err)
+	}
+
+	// check if the custom version is an exact version
+	if v, err := semver.NewVersion(g.Version); err == nil {
+		return def != nil && v.Equal(def), nil
+	}
+
+	c, err := semver.NewConstraint(g.Version)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrorInvalidGHVersion, g.Version)
+	}
+
+	// a constraint can't be resolved to a version to download
+	if def == nil || !c.Check(def) {
+		return false, fmt.Errorf("%w: %s (bundled: %s)", ErrorUnsatisfiedGHVersion, g.Version, g.Default)
+	}
+
+	return true, nil
+}
+
+// render is a helper function to substitute the
+// version, OS and architecture placeholders in s.
+func (g *GH) render(s string) string {
+	version, _ := g.version()
+
+	return strings.NewReplacer(
+		"{version}", version,
+		"{os}", runtime.GOOS,
+		"{arch}", runtime.GOARCH,
+	).Replace(s)
+}
+
+// checksums is a helper function to return the URL of the
+// checksums file to verify the gh download against. The
+// checksums published with the gh release are only used
+// when downloading from the default URL.
+func (g *GH) checksums() string {
+	if len(g.Checksums) > 0 {
+		return g.render(g.Checksums)
+	}
+
+	if len(g.Tarball) == 0 && len(g.URL) == 0 {
+		return g.render(_checksums)
+	}
+
+	return ""
+}
+
+// Source returns the go-getter source used to fetch
+// the gh binary from the provided configuration.
+func (g *GH) Source() string {
+	// directory containing the gh binary within the tarball
+	subdir := g.render(_archive)
+
+	src := g.Tarball
+
+	// check if a local tarball is provided
+	if len(src) == 0 {
+		src = g.URL
+	}
+
+	// check if a custom URL template is provided
+	if len(src) == 0 {
+		src = _download
+	}
+
+	src = fmt.Sprintf("%s//%s", g.render(src), subdir)
+
+	// verify the tarball against the checksums file before it is extracted
+	if sums := g.checksums(); len(sums) > 0 {
+		src = fmt.Sprintf("%s?checksum=file:%s", src, sums)
+	}
+
+	return src
+}
+
+// Exec downloads and installs the custom version of the gh cli.
+func (g *GH) Exec(ctx context.Context) error {
+	logrus.Infof("custom gh version requested: %s", g.Version)
 
 	// use custom filesystem which enables us to test
 	a := &afero.Afero{
 		Fs: appFS,
 	}
 
-	// check if the custom version matches the default version
-	if strings.EqualFold(customVer, defaultVer) {
+	// check if the default version satisfies the custom version
+	ok, err := g.satisfied()
+	if err != nil {
+		return err
+	}
+
+	if ok {
 		// the gh versions match so no action required
 		return nil
 	}
 
-	logrus.Debugf("custom version does not match default: %s", defaultVer)
+	logrus.Debugf("custom version does not match default: %s", g.Default)
 	// rename the old gh binary since we can't overwrite it for now
 	// https://github.com/hashicorp/go-getter/issues/219
-	err := a.Rename(_gh, fmt.Sprintf("%s.default", _gh))
+	err = a.Rename(_gh, fmt.Sprintf("%s.default", _gh))
 	if err != nil {
 		return err
 	}
 
-	// create the download URL to install gh
-	url := fmt.Sprintf(_download, customVer, customVer, runtime.GOOS, runtime.GOARCH, customVer, runtime.GOOS, runtime.GOARCH)
+	// check if a cache directory is provided
+	if len(g.CacheDir) > 0 {
+		// attempt to install the gh binary from the cache
+		ok, err = g.fromCache()
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+	}
 
-	logrus.Infof("downloading gh version from: %s", url)
-	// send the HTTP request to install gh
-	_, err = getter.Get(ctx, _ghTmp, url)
+	// create the source to install gh from
+	src := g.Source()
+
+	logrus.Infof("downloading gh version from: %s", src)
+	// fetch the gh binary from the source
+	_, err = getter.Get(ctx, _ghTmp, src)
 	if err != nil {
 		return err
 	}
@@ -65,5 +210,46 @@ func install(ctx context.Context, customVer, defaultVer string) error {
 		return err
 	}
 
+	// check if a cache directory is provided
+	if len(g.CacheDir) == 0 {
+		return nil
+	}
+
+	// only cache gh binaries verified against a checksums file
+	if len(g.checksums()) == 0 {
+		logrus.Warnf("not caching gh binary from %s without a checksums file to verify it against", src)
+
+		return nil
+	}
+
+	// store the gh binary in the cache for future builds
+	err = g.toCache()
+	if err != nil {
+		logrus.Warnf("unable to cache gh binary: %v", err)
+	}
+
+	return nil
+}
+
+// Validate verifies the GH is properly configured.
+func (g *GH) Validate() error {
+	logrus.Trace("validating gh configuration")
+
+	// check if a custom gh version was requested
+	if len(g.Version) == 0 {
+		return nil
+	}
+
+	// check if the custom version is an exact version
+	if _, ok := g.version(); ok {
+		return nil
+	}
+
+	// verify the custom version is a valid constraint
+	_, err := semver.NewConstraint(g.Version)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrorInvalidGHVersion, g.Version)
+	}
+
 	return nil
 }
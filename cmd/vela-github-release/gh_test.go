@@ -3,34 +3,49 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/spf13/afero"
 )
 
-func TestGithub_CLI_install(t *testing.T) {
+func TestGithub_CLI_Exec(t *testing.T) {
 	// setup filesystem
 	appFS = afero.NewMemMapFs()
 
+	// setup types
+	g := &GH{
+		Default: "2.14.4",
+		Version: "2.14.4",
+	}
+
 	// run test
-	err := install(t.Context(), "2.14.4", "2.14.4")
+	err := g.Exec(t.Context())
 	if err != nil {
-		t.Errorf("install returned err: %v", err)
+		t.Errorf("Exec returned err: %v", err)
 	}
 }
 
-func TestGithub_CLI_install_NoBinary(t *testing.T) {
+func TestGithub_CLI_Exec_NoBinary(t *testing.T) {
 	// setup filesystem
 	appFS = afero.NewMemMapFs()
 
+	// setup types
+	g := &GH{
+		Default: "2.14.4",
+		Version: "2.14.3",
+	}
+
 	// run test
-	err := install(t.Context(), "2.14.3", "2.14.4")
+	err := g.Exec(t.Context())
 	if err == nil {
-		t.Errorf("install should have returned err ")
+		t.Errorf("Exec should have returned err ")
 	}
 }
 
-func TestGithub_CLI_install_NotWritable(t *testing.T) {
+func TestGithub_CLI_Exec_NotWritable(t *testing.T) {
 	// setup filesystem
 	appFS = afero.NewMemMapFs()
 
@@ -44,9 +59,164 @@ func TestGithub_CLI_install_NotWritable(t *testing.T) {
 		t.Errorf("Unable to write file %s: %v", _gh, err)
 	}
 
+	// setup types
+	g := &GH{
+		Default: "2.14.4",
+		Tarball: "testdata/missing.tar.gz",
+		Version: "2.14.3",
+	}
+
 	// run test
-	err = install(t.Context(), "2.14.3", "2.14.4")
+	err = g.Exec(t.Context())
 	if err == nil {
-		t.Errorf("install should have returned err")
+		t.Errorf("Exec should have returned err")
+	}
+}
+
+func TestGithub_CLI_Source(t *testing.T) {
+	// setup types
+	platform := fmt.Sprintf("2.14.3_%s_%s", runtime.GOOS, runtime.GOARCH)
+
+	tests := []struct {
+		name string
+		g    *GH
+		want string
+	}{
+		{
+			name: "default",
+			g: &GH{
+				Version: "2.14.3",
+			},
+			want: fmt.Sprintf("https://github.com/cli/cli/releases/download/v2.14.3/gh_%s.tar.gz//gh_%s/bin?checksum=file:https://github.com/cli/cli/releases/download/v2.14.3/gh_2.14.3_checksums.txt", platform, platform),
+		},
+		{
+			name: "mirror",
+			g: &GH{
+				URL:     "https://artifacts.example.com/gh/{version}/gh_{version}_{os}_{arch}.tar.gz",
+				Version: "2.14.3",
+			},
+			want: fmt.Sprintf("https://artifacts.example.com/gh/2.14.3/gh_%s.tar.gz//gh_%s/bin", platform, platform),
+		},
+		{
+			name: "mirror with checksums",
+			g: &GH{
+				Checksums: "https://artifacts.example.com/gh/{version}/gh_{version}_checksums.txt",
+				URL:       "https://artifacts.example.com/gh/{version}/gh_{version}_{os}_{arch}.tar.gz",
+				Version:   "2.14.3",
+			},
+			want: fmt.Sprintf("https://artifacts.example.com/gh/2.14.3/gh_%s.tar.gz//gh_%s/bin?checksum=file:https://artifacts.example.com/gh/2.14.3/gh_2.14.3_checksums.txt", platform, platform),
+		},
+		{
+			name: "local path",
+			g: &GH{
+				URL:     "/mnt/gh/gh_{version}_{os}_{arch}.tar.gz",
+				Version: "2.14.3",
+			},
+			want: fmt.Sprintf("/mnt/gh/gh_%s.tar.gz//gh_%s/bin", platform, platform),
+		},
+		{
+			name: "tarball",
+			g: &GH{
+				Tarball: "/vela/cache/gh.tar.gz",
+				URL:     "https://artifacts.example.com/gh_{version}.tar.gz",
+				Version: "2.14.3",
+			},
+			want: fmt.Sprintf("/vela/cache/gh.tar.gz//gh_%s/bin", platform),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.g.Source()
+
+			if got != test.want {
+				t.Errorf("Source is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithub_CLI_Exec_Constraint(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	tests := []struct {
+		name    string
+		g       *GH
+		wantErr error
+	}{
+		{
+			name: "prefixed version",
+			g: &GH{
+				Default: "2.40.0",
+				Version: "v2.40.0",
+			},
+		},
+		{
+			name: "satisfied constraint",
+			g: &GH{
+				Default: "2.87.3",
+				Version: ">=2.40",
+			},
+		},
+		{
+			name: "unsatisfied constraint",
+			g: &GH{
+				Default: "2.14.4",
+				Version: ">=2.40",
+			},
+			wantErr: ErrorUnsatisfiedGHVersion,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.g.Exec(t.Context())
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Exec error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGithub_CLI_Source_PrefixedVersion(t *testing.T) {
+	// setup types
+	g := &GH{
+		Tarball: "gh_{version}.tar.gz",
+		Version: "v2.40.0",
+	}
+
+	want := fmt.Sprintf("gh_2.40.0.tar.gz//gh_2.40.0_%s_%s/bin", runtime.GOOS, runtime.GOARCH)
+
+	got := g.Source()
+
+	if got != want {
+		t.Errorf("Source is %v, want %v", got, want)
+	}
+}
+
+func TestGithub_CLI_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr error
+	}{
+		{name: "no version", version: ""},
+		{name: "version", version: "2.40.0"},
+		{name: "prefixed version", version: "v2.40.0"},
+		{name: "constraint", version: ">=2.40, <3"},
+		{name: "invalid version", version: "2.40.0-", wantErr: ErrorInvalidGHVersion},
+		{name: "typo", version: "latets", wantErr: ErrorInvalidGHVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &GH{Version: test.version}
+
+			err := g.Validate()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Validate error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
 	}
 }
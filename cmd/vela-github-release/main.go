@@ -91,14 +91,21 @@ func run(ctx context.Context, c *cli.Command) error {
 		"registry": "https://hub.docker.com/r/target/vela-github-release",
 	}).Info("Vela Github Release Plugin")
 
-	// capture custom gh version requested
-	version := c.String("gh.version")
-
-	// check is a custom gh version was requested
-	if len(version) > 0 {
-		// attempt to install the custom gh version
-		if err := install(ctx, version, os.Getenv("PLUGIN_GH_VERSION")); err != nil {
-			return err
+	// create the tag policy shared by actions that publish a tag
+	policy := &TagPolicy{
+		Pattern:    c.String("tag.pattern"),
+		Prefix:     c.String("tag.prefix"),
+		Prerelease: c.StringSlice("tag.prerelease"),
+		Semver:     c.Bool("tag.semver"),
+	}
+
+	// capture the releases to download which are provided as JSON
+	var releases []DownloadEntry
+
+	if len(c.String("download.releases")) > 0 {
+		err := json.Unmarshal([]byte(c.String("download.releases")), &releases)
+		if err != nil {
+			return fmt.Errorf("unable to parse download releases: %w", err)
 		}
 	}
 
@@ -111,37 +118,117 @@ func run(ctx context.Context, c *cli.Command) error {
 			Path:     tokenFile,
 			Token:    c.String("config.token"),
 		},
+		// copy configuration
+		Copy: &Copy{
+			DestinationTag: c.String("copy.destination_tag"),
+			Exclude:        c.StringSlice("exclude"),
+			Policy:         policy,
+			Repo:           c.String("copy.repo"),
+			SourceHostname: c.String("copy.source_hostname"),
+			SourceRepo:     c.String("copy.source_repo"),
+			SourceToken:    c.String("copy.source_token"),
+			Tag:            c.String("tag"),
+			Target:         c.String("copy.target"),
+		},
 		// create configuration
 		Create: &Create{
-			Draft:      c.Bool("create.draft"),
-			Files:      c.StringSlice("files"),
-			Notes:      c.String("create.notes"),
-			NotesFile:  c.String("create.notes_file"),
-			Prerelease: c.Bool("create.prerelease"),
-			Tag:        c.String("tag"),
-			Target:     c.String("create.target"),
-			Title:      c.String("create.title"),
+			Archive:             c.String("archive"),
+			ArchiveName:         c.String("archive_name"),
+			Changelog:           c.Bool("create.changelog"),
+			ChangelogFrom:       c.String("create.changelog_from"),
+			DiscussionCategory:  c.String("create.discussion_category"),
+			Draft:               c.Bool("create.draft"),
+			Exclude:             c.StringSlice("exclude"),
+			Files:               c.StringSlice("files"),
+			GenerateNotes:       c.Bool("create.generate_notes"),
+			Latest:              c.String("create.latest"),
+			Notes:               c.String("create.notes"),
+			NotesFile:           c.String("create.notes_file"),
+			NotesStartTag:       c.String("create.notes_start_tag"),
+			Policy:              policy,
+			Prerelease:          c.String("create.prerelease"),
+			PrereleaseID:        c.String("create.prerelease_id"),
+			StableDefaultBranch: c.Bool("create.stable_default_branch"),
+			StageDir:            c.String("stage_dir"),
+			Strict:              c.Bool("strict"),
+			Tag:                 c.String("tag"),
+			Target:              c.String("create.target"),
+			Title:               c.String("create.title"),
+			VerifyTag:           c.Bool("create.verify_tag"),
 		},
 		// delete configuration
 		Delete: &Delete{
-			Yes: c.Bool("delete.yes"),
-			Tag: c.String("tag"),
+			CleanupTag: c.Bool("delete.cleanup_tag"),
+			Yes:        c.Bool("delete.yes"),
+			Tag:        c.String("tag"),
 		},
 		// download configuration
 		Download: &Download{
-			Directory: c.String("download.dir"),
-			Patterns:  c.StringSlice("download.patterns"),
-			Tag:       c.String("tag"),
+			DeleteArchive:   c.Bool("download.delete_archive"),
+			Directory:       c.String("download.dir"),
+			Existing:        c.String("download.existing"),
+			Extract:         c.Bool("download.extract"),
+			ExtractDir:      c.String("download.extract_dir"),
+			Patterns:        c.StringSlice("download.patterns"),
+			Releases:        releases,
+			SourceArchives:  c.StringSlice("download.source_archives"),
+			SourceOnly:      c.Bool("download.source_only"),
+			StripComponents: c.Int("download.strip_components"),
+			Tag:             c.String("tag"),
+		},
+		// gh configuration
+		GH: &GH{
+			CacheDir:   c.String("gh.cache_dir"),
+			CacheLimit: c.Int("gh.cache_limit"),
+			CacheSize:  c.Int("gh.cache_size"),
+			Checksums:  c.String("gh.checksums"),
+			Default:    os.Getenv("PLUGIN_GH_VERSION"),
+			Tarball:    c.String("gh.tarball"),
+			URL:        c.String("gh.url"),
+			Version:    c.String("gh.version"),
 		},
 		// list configuration
 		List: &List{
 			Limit: c.Int("list.limit"),
 		},
+		// mirror configuration
+		Mirror: &Mirror{
+			Exclude:  c.StringSlice("exclude"),
+			Hostname: c.String("config.hostname"),
+			Pattern:  c.String("mirror.pattern"),
+			Range:    c.String("mirror.range"),
+			Repo:     c.String("mirror.repo"),
+			Source: &Config{
+				Action:   mirrorAction,
+				Hostname: c.String("mirror.source_hostname"),
+				Token:    c.String("mirror.source_token"),
+			},
+			SourceRepo: c.String("mirror.source_repo"),
+			Token:      c.String("config.token"),
+		},
+		// prune configuration
+		Prune: &Prune{
+			CleanupTag: c.Bool("prune.cleanup_tag"),
+			DryRun:     c.Bool("prune.dry_run"),
+			Keep:       c.Int("prune.keep"),
+			OlderThan:  c.Duration("prune.older_than"),
+			Pattern:    c.String("prune.pattern"),
+			Sort:       c.String("prune.sort"),
+			Status:     c.StringSlice("prune.status"),
+		},
 		// upload configuration
 		Upload: &Upload{
-			Clobber: c.Bool("upload.clobber"),
-			Files:   c.StringSlice("files"),
-			Tag:     c.String("tag"),
+			Archive:          c.String("archive"),
+			ArchiveName:      c.String("archive_name"),
+			ChecksumManifest: c.String("upload.checksum_manifest"),
+			Clobber:          c.Bool("upload.clobber"),
+			Exclude:          c.StringSlice("exclude"),
+			Files:            c.StringSlice("files"),
+			Policy:           policy,
+			SkipUnchanged:    c.Bool("upload.skip_unchanged"),
+			StageDir:         c.String("stage_dir"),
+			Strict:           c.Bool("strict"),
+			Tag:              c.String("tag"),
 		},
 		// view configuration
 		View: &View{
@@ -0,0 +1,292 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+)
+
+const mirrorAction = "mirror"
+
+var (
+	// ErrorNoMirrorSourceRepo is returned when the plugin is missing the mirror source repository.
+	ErrorNoMirrorSourceRepo = errors.New("no mirror source repo provided")
+
+	// ErrorInvalidMirrorPattern is returned when the plugin is provided a mirror pattern that fails to compile.
+	ErrorInvalidMirrorPattern = errors.New("invalid mirror pattern provided")
+
+	// ErrorInvalidMirrorRange is returned when the plugin is provided an invalid mirror semver range.
+	ErrorInvalidMirrorRange = errors.New("invalid mirror range provided")
+)
+
+// Mirror represents the plugin configuration for Mirror config information.
+type Mirror struct {
+	// list of patterns for assets to skip
+	Exclude []string
+	// hostname of the destination repository
+	Hostname string
+	// regular expression the tags of mirrored releases must match
+	Pattern string
+	// semantic version range the tags of mirrored releases must satisfy (e.g. >=1.2, <2)
+	Range string
+	// destination repository in the OWNER/REPO format (default: current repository)
+	Repo string
+	// hostname and token to authenticate to the source host
+	Source *Config
+	// source repository in the OWNER/REPO format
+	SourceRepo string
+	// token to authenticate to the destination host
+	Token string
+
+	// pattern and range compiled when the configuration is validated
+	pattern    *regexp.Regexp
+	constraint *semver.Constraints
+}
+
+// selected is a helper function to check if the release
+// matches the mirror pattern and semantic version range.
+func (m *Mirror) selected(r release) bool {
+	// drafts are only visible to maintainers of the source repository
+	if r.IsDraft {
+		return false
+	}
+
+	if m.pattern != nil && !m.pattern.MatchString(r.TagName) {
+		return false
+	}
+
+	if m.constraint != nil {
+		v := r.Version()
+		if v == nil || !m.constraint.Check(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// missingAssets is a helper function to return the assets of the source
+// release that are missing from the destination release.
+func missingAssets(src, dst release) []releaseAsset {
+	names := make(map[string]bool)
+
+	for _, a := range dst.Assets {
+		names[a.Name] = true
+	}
+
+	var missing []releaseAsset
+
+	for _, a := range src.Assets {
+		if !names[a.Name] {
+			missing = append(missing, a)
+		}
+	}
+
+	return missing
+}
+
+// remotes returns the source and destination repositories along with
+// the tokens to authenticate to their hosts, since a single gh login or
+// token in the environment would otherwise apply to both.
+func (m *Mirror) remotes() (remote, remote) {
+	from := remote{Repo: m.SourceRepo}
+
+	if m.Source != nil {
+		from = remote{
+			Repo:  repoPath(m.Source.Hostname, m.SourceRepo),
+			Token: m.Source.Token,
+		}
+	}
+
+	to := remote{
+		Repo:  repoPath(m.Hostname, m.Repo),
+		Token: m.Token,
+	}
+
+	return from, to
+}
+
+// Exec formats and runs the commands for applying
+// the provided configuration to the resources.
+func (m *Mirror) Exec(ctx context.Context) error {
+	logrus.Debug("running mirror with provided configuration")
+
+	from, to := m.remotes()
+
+	sources, err := listReleases(ctx, from, _releaseLimit)
+	if err != nil {
+		return err
+	}
+
+	destinations, err := listReleases(ctx, to, _releaseLimit)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+
+	for _, r := range destinations {
+		existing[r.TagName] = true
+	}
+
+	var selected []release
+
+	for _, r := range sources {
+		if m.selected(r) {
+			selected = append(selected, r)
+		}
+	}
+
+	// mirror the oldest releases first so the newest release is created last
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].CreatedAt.Before(selected[j].CreatedAt)
+	})
+
+	var report []string
+
+	for _, r := range selected {
+		src, err := viewRelease(ctx, from, r.TagName)
+		if err != nil {
+			return err
+		}
+
+		// check if the release is missing from the destination
+		if !existing[r.TagName] {
+			added, err := copyRelease(ctx, src, from, to, r.TagName, "", m.Exclude)
+			if err != nil {
+				return err
+			}
+
+			report = append(report, fmt.Sprintf("added release %s with %d asset(s)", r.TagName, len(added)))
+
+			continue
+		}
+
+		dst, err := viewRelease(ctx, to, r.TagName)
+		if err != nil {
+			return err
+		}
+
+		src.Assets = missingAssets(src, dst)
+
+		added, err := uploadMissing(ctx, src, from, to, m.Exclude)
+		if err != nil {
+			return err
+		}
+
+		if len(added) > 0 {
+			report = append(report, fmt.Sprintf("added asset(s) %s to release %s", strings.Join(added, ", "), r.TagName))
+		}
+	}
+
+	if len(report) == 0 {
+		logrus.Infof("all releases from %s are mirrored", from.Repo)
+
+		return nil
+	}
+
+	fmt.Printf("mirrored %s to %s:\n", from.Repo, to.Repo)
+
+	for _, line := range report {
+		fmt.Printf("  %s\n", line)
+	}
+
+	return nil
+}
+
+// uploadMissing is a helper function to pass the assets of the source
+// release through the plugin to the release of the same tag in the
+// destination repository, returning the names of the uploaded assets.
+func uploadMissing(ctx context.Context, src release, from, to remote, exclude []string) ([]string, error) {
+	// create a directory to pass the assets through
+	dir, err := os.MkdirTemp("", "vela-github-release-mirror")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	assets, err := downloadAssets(ctx, src, from, dir, exclude)
+	if err != nil || len(assets) == 0 {
+		return nil, err
+	}
+
+	// run the upload command for the destination repository
+	err = execCmd(uploadCommand(ctx, src.TagName, to, assets), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, a := range assets {
+		names = append(names, a.Name)
+	}
+
+	return names, nil
+}
+
+// uploadCommand is a helper function to create the command to upload
+// the assets to the release of the tag in the destination repository.
+func uploadCommand(ctx context.Context, tag string, to remote, assets []asset) *exec.Cmd {
+	u := &Upload{
+		Tag:    tag,
+		assets: assets,
+	}
+
+	return withRemote(u.Command(ctx), to)
+}
+
+// Validate verifies the Mirror is properly configured.
+func (m *Mirror) Validate() error {
+	logrus.Trace("validating mirror configuration")
+
+	// verify mirror source repo is provided
+	if len(m.SourceRepo) == 0 {
+		return ErrorNoMirrorSourceRepo
+	}
+
+	// verify the source can be authenticated to
+	if m.Source != nil {
+		// a source on the destination host uses the destination token
+		if len(m.Source.Token) == 0 && m.Source.Hostname == m.Hostname {
+			m.Source.Token = m.Token
+		}
+
+		err := m.Source.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid mirror source: %w", err)
+		}
+	}
+
+	// verify mirror pattern compiles
+	if len(m.Pattern) > 0 {
+		pattern, err := regexp.Compile(m.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrorInvalidMirrorPattern, err)
+		}
+
+		m.pattern = pattern
+	}
+
+	// verify mirror range is a valid semantic version constraint
+	if len(m.Range) > 0 {
+		constraint, err := semver.NewConstraint(m.Range)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrorInvalidMirrorRange, err)
+		}
+
+		m.constraint = constraint
+	}
+
+	return nil
+}
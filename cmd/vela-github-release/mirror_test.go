@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestGithubRelease_Mirror_selected(t *testing.T) {
+	tests := []struct {
+		name   string
+		mirror *Mirror
+		r      release
+		want   bool
+	}{
+		{
+			name:   "no filters",
+			mirror: &Mirror{},
+			r:      release{TagName: "nightly"},
+			want:   true,
+		},
+		{
+			name:   "draft",
+			mirror: &Mirror{},
+			r:      release{TagName: "v1.0.0", IsDraft: true},
+		},
+		{
+			name:   "pattern",
+			mirror: &Mirror{Pattern: `^v1\.`},
+			r:      release{TagName: "v1.2.0"},
+			want:   true,
+		},
+		{
+			name:   "pattern mismatch",
+			mirror: &Mirror{Pattern: `^v1\.`},
+			r:      release{TagName: "v2.0.0"},
+		},
+		{
+			name:   "range",
+			mirror: &Mirror{Range: ">=1.2, <2"},
+			r:      release{TagName: "v1.5.3"},
+			want:   true,
+		},
+		{
+			name:   "range mismatch",
+			mirror: &Mirror{Range: ">=1.2, <2"},
+			r:      release{TagName: "v2.0.0"},
+		},
+		{
+			name:   "range without semver",
+			mirror: &Mirror{Range: ">=1.2"},
+			r:      release{TagName: "nightly"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mirror.SourceRepo = "octocat/hello"
+
+			err := test.mirror.Validate()
+			if err != nil {
+				t.Errorf("Validate returned err: %v", err)
+			}
+
+			got := test.mirror.selected(test.r)
+
+			if got != test.want {
+				t.Errorf("selected is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_missingAssets(t *testing.T) {
+	// setup types
+	src := release{
+		Assets: []releaseAsset{
+			{Name: "app_linux_amd64.tar.gz"},
+			{Name: "app_darwin_arm64.tar.gz"},
+			{Name: "checksums.txt"},
+		},
+	}
+
+	dst := release{
+		Assets: []releaseAsset{
+			{Name: "app_linux_amd64.tar.gz"},
+		},
+	}
+
+	got := missingAssets(src, dst)
+
+	if len(got) != 2 || got[0].Name != "app_darwin_arm64.tar.gz" || got[1].Name != "checksums.txt" {
+		t.Errorf("missing assets are %v, want app_darwin_arm64.tar.gz and checksums.txt", got)
+	}
+}
+
+func TestGithubRelease_Mirror_Exec_Error(t *testing.T) {
+	// setup types
+	m := &Mirror{
+		Hostname:   "git.example.com",
+		Repo:       "tools/hello",
+		SourceRepo: "octocat/hello",
+	}
+
+	err := m.Exec(t.Context())
+	if err == nil {
+		t.Errorf("Exec should have returned err")
+	}
+}
+
+func TestGithubRelease_Mirror_Commands_Tokens(t *testing.T) {
+	// setup types
+	m := &Mirror{
+		Hostname:   "git.example.com",
+		Repo:       "tools/hello",
+		Source:     &Config{Hostname: "github.com", Token: "source"},
+		SourceRepo: "octocat/hello",
+		Token:      "destination",
+	}
+
+	src := release{
+		Assets:  []releaseAsset{{Name: "app.tar.gz"}},
+		TagName: "v1.0.0",
+	}
+
+	from, to := m.remotes()
+
+	download, assets := downloadCommand(t.Context(), src, from, t.TempDir(), nil)
+
+	tests := []struct {
+		name  string
+		cmd   *exec.Cmd
+		repo  string
+		token string
+	}{
+		{name: "list source", cmd: listCommand(t.Context(), from, _releaseLimit), repo: "github.com/octocat/hello", token: "source"},
+		{name: "list destination", cmd: listCommand(t.Context(), to, _releaseLimit), repo: "git.example.com/tools/hello", token: "destination"},
+		{name: "view source", cmd: viewCommand(t.Context(), from, src.TagName), repo: "github.com/octocat/hello", token: "source"},
+		{name: "view destination", cmd: viewCommand(t.Context(), to, src.TagName), repo: "git.example.com/tools/hello", token: "destination"},
+		{name: "download", cmd: download, repo: "github.com/octocat/hello", token: "source"},
+		{name: "create", cmd: createCommand(t.Context(), src, to, src.TagName, "", assets), repo: "git.example.com/tools/hello", token: "destination"},
+		{name: "upload", cmd: uploadCommand(t.Context(), src.TagName, to, assets), repo: "git.example.com/tools/hello", token: "destination"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, want := range []string{"GH_REPO=" + test.repo, "GH_TOKEN=" + test.token, "GH_ENTERPRISE_TOKEN=" + test.token} {
+				if !slices.Contains(test.cmd.Env, want) {
+					t.Errorf("environment does not contain %s", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Mirror_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirror  *Mirror
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			mirror: &Mirror{Pattern: `^v`, Range: ">=1.2, <2", SourceRepo: "octocat/hello"},
+		},
+		{
+			name:    "no source repo",
+			mirror:  &Mirror{},
+			wantErr: ErrorNoMirrorSourceRepo,
+		},
+		{
+			name: "source on destination host",
+			mirror: &Mirror{
+				Hostname:   "github.com",
+				Source:     &Config{Action: mirrorAction, Hostname: "github.com"},
+				SourceRepo: "octocat/hello",
+				Token:      "destination",
+			},
+		},
+		{
+			name: "no source token",
+			mirror: &Mirror{
+				Hostname:   "git.example.com",
+				Source:     &Config{Action: mirrorAction, Hostname: "github.com"},
+				SourceRepo: "octocat/hello",
+				Token:      "destination",
+			},
+			wantErr: ErrorNoConfigGitToken,
+		},
+		{
+			name:    "invalid pattern",
+			mirror:  &Mirror{Pattern: `(`, SourceRepo: "octocat/hello"},
+			wantErr: ErrorInvalidMirrorPattern,
+		},
+		{
+			name:    "invalid range",
+			mirror:  &Mirror{Range: ">=one", SourceRepo: "octocat/hello"},
+			wantErr: ErrorInvalidMirrorRange,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.mirror.Validate()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Validate error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
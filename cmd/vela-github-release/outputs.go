@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// writeOutputs is a helper function to append the provided
+// outputs to the Vela outputs file for use in later steps.
+//
+// https://go-vela.github.io/docs/usage/outputs/
+func writeOutputs(outputs map[string]string) error {
+	// capture the path to the Vela outputs file
+	path := os.Getenv("VELA_OUTPUTS")
+
+	// check if the outputs file is provided
+	if len(path) == 0 {
+		logrus.Debug("VELA_OUTPUTS not set, skipping writing outputs")
+
+		return nil
+	}
+
+	f, err := appFS.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+
+	// write the outputs in a deterministic order
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		logrus.Debugf("writing output %s=%s", key, outputs[key])
+
+		_, err = fmt.Fprintf(f, "%s=%s\n", key, outputs[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
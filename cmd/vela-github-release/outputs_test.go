@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestGithubRelease_writeOutputs(t *testing.T) {
+	// setup filesystem
+	appFS = afero.NewMemMapFs()
+
+	a := &afero.Afero{
+		Fs: appFS,
+	}
+
+	// setup environment
+	t.Setenv("VELA_OUTPUTS", "/vela/outputs/.env")
+
+	err := a.WriteFile("/vela/outputs/.env", []byte("EXISTING=true\n"), 0644)
+	if err != nil {
+		t.Errorf("Unable to write outputs file: %v", err)
+	}
+
+	err = writeOutputs(map[string]string{"B": "2", "A": "1"})
+	if err != nil {
+		t.Errorf("writeOutputs returned err: %v", err)
+	}
+
+	got, err := a.ReadFile("/vela/outputs/.env")
+	if err != nil {
+		t.Errorf("Unable to read outputs file: %v", err)
+	}
+
+	want := "EXISTING=true\nA=1\nB=2\n"
+
+	if string(got) != want {
+		t.Errorf("outputs file is %q, want %q", got, want)
+	}
+}
+
+func TestGithubRelease_writeOutputs_NoFile(t *testing.T) {
+	// setup environment
+	t.Setenv("VELA_OUTPUTS", "")
+
+	err := writeOutputs(map[string]string{"A": "1"})
+	if err != nil {
+		t.Errorf("writeOutputs returned err: %v", err)
+	}
+}
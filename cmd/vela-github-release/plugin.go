@@ -23,14 +23,22 @@ var (
 type Plugin struct {
 	// config arguments loaded for the plugin
 	Config *Config
+	// copy arguments loaded for the plugin
+	Copy *Copy
 	// create arguments loaded for the plugin
 	Create *Create
 	// delete arguments loaded for the plugin
 	Delete *Delete
 	// download arguments loaded for the plugin
 	Download *Download
+	// gh arguments loaded for the plugin
+	GH *GH
 	// list arguments loaded for the plugin
 	List *List
+	// mirror arguments loaded for the plugin
+	Mirror *Mirror
+	// prune arguments loaded for the plugin
+	Prune *Prune
 	// upload arguments loaded for the plugin
 	Upload *Upload
 	// view arguments loaded fo rthe plugin
@@ -41,6 +49,15 @@ type Plugin struct {
 func (p *Plugin) Exec(ctx context.Context) error {
 	logrus.Debug("running plugin with provided configuration")
 
+	// check if a custom gh version was requested
+	if len(p.GH.Version) > 0 {
+		// attempt to install the custom gh version
+		err := p.GH.Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// output gh version for troubleshooting
 	err := execCmd(versionCmd(ctx), nil)
 	if err != nil {
@@ -55,6 +72,9 @@ func (p *Plugin) Exec(ctx context.Context) error {
 
 	// execute action specific configuration
 	switch p.Config.Action {
+	case copyAction:
+		// execute copy action
+		return p.Copy.Exec(ctx)
 	case createAction:
 		// execute create action
 		return p.Create.Exec(ctx)
@@ -67,6 +87,12 @@ func (p *Plugin) Exec(ctx context.Context) error {
 	case listAction:
 		// execute list action
 		return p.List.Exec(ctx)
+	case mirrorAction:
+		// execute mirror action
+		return p.Mirror.Exec(ctx)
+	case pruneAction:
+		// execute prune action
+		return p.Prune.Exec(ctx)
 	case uploadAction:
 		// execute upload action
 		return p.Upload.Exec(ctx)
@@ -75,13 +101,16 @@ func (p *Plugin) Exec(ctx context.Context) error {
 		return p.View.Exec(ctx)
 	default:
 		return fmt.Errorf(
-			"%w: %s (Valid actions: %s, %s, %s, %s, %s, %s)",
+			"%w: %s (Valid actions: %s, %s, %s, %s, %s, %s, %s, %s, %s)",
 			ErrInvalidAction,
 			p.Config.Action,
+			copyAction,
 			createAction,
 			deleteAction,
 			downloadAction,
 			listAction,
+			mirrorAction,
+			pruneAction,
 			uploadAction,
 			viewAction,
 		)
@@ -92,14 +121,23 @@ func (p *Plugin) Exec(ctx context.Context) error {
 func (p *Plugin) Validate() error {
 	logrus.Debug("validating plugin configuration")
 
+	// validate gh configuration
+	err := p.GH.Validate()
+	if err != nil {
+		return err
+	}
+
 	// validate config configuration
-	err := p.Config.Validate()
+	err = p.Config.Validate()
 	if err != nil {
 		return err
 	}
 
 	// validate action specific configuration
 	switch p.Config.Action {
+	case copyAction:
+		// validate copy configuration
+		return p.Copy.Validate()
 	case createAction:
 		// validate create configuration
 		return p.Create.Validate()
@@ -112,6 +150,12 @@ func (p *Plugin) Validate() error {
 	case listAction:
 		// validate list configuration
 		return p.List.Validate()
+	case mirrorAction:
+		// validate mirror configuration
+		return p.Mirror.Validate()
+	case pruneAction:
+		// validate prune configuration
+		return p.Prune.Validate()
 	case uploadAction:
 		// validate upload configuration
 		return p.Upload.Validate()
@@ -120,13 +164,16 @@ func (p *Plugin) Validate() error {
 		return p.View.Validate()
 	default:
 		return fmt.Errorf(
-			"%w: %s (Valid actions: %s, %s, %s, %s, %s, %s)",
+			"%w: %s (Valid actions: %s, %s, %s, %s, %s, %s, %s, %s, %s)",
 			ErrInvalidAction,
 			p.Config.Action,
+			copyAction,
 			createAction,
 			deleteAction,
 			downloadAction,
 			listAction,
+			mirrorAction,
+			pruneAction,
 			uploadAction,
 			viewAction,
 		)
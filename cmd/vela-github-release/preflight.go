@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// _maxAssetSize is the size GitHub requires release assets to be smaller than (2 GiB).
+	_maxAssetSize = 2 << 30
+	// _maxAssets is the largest number of assets GitHub accepts on a release.
+	_maxAssets = 1000
+)
+
+var (
+	// ErrorAssetTooLarge is returned when an asset exceeds the GitHub size limit.
+	ErrorAssetTooLarge = errors.New("asset exceeds the 2 GiB size limit")
+
+	// ErrorAssetLimit is returned when a release would exceed the GitHub asset limit.
+	ErrorAssetLimit = errors.New("release exceeds the limit of 1000 assets")
+
+	// ErrorAssetNameNormalized is returned in strict mode when GitHub would rename an asset.
+	ErrorAssetNameNormalized = errors.New("asset name is renamed by GitHub")
+
+	// assetNameRegex matches the characters GitHub replaces in asset names along with adjacent periods.
+	assetNameRegex = regexp.MustCompile(`\.*[^A-Za-z0-9._+@-]+\.*`)
+)
+
+// normalizeAssetName is a helper function to return the name
+// GitHub stores for an asset uploaded with the provided name.
+func normalizeAssetName(name string) string {
+	return strings.Trim(assetNameRegex.ReplaceAllString(name, "."), ".")
+}
+
+// preflight is a helper function to verify the provided assets fit
+// the GitHub size, count and naming limits alongside the existing
+// assets of the release. All problems are reported together, and
+// renamed assets are only reported as errors in strict mode, while
+// new assets stored under the same name are always reported.
+func preflight(assets []asset, existing []releaseAsset, strict bool) error {
+	logrus.Trace("running preflight checks for assets")
+
+	var errs []error
+
+	// track the asset names already stored on the release
+	stored := make(map[string]bool)
+
+	for _, e := range existing {
+		stored[e.Name] = true
+	}
+
+	// track the new asset producing each stored name
+	names := make(map[string]string)
+
+	count := len(existing)
+
+	for _, a := range assets {
+		// a missing file is reported by gh when uploading the asset
+		info, err := os.Stat(a.File())
+		if err == nil && info.Size() >= _maxAssetSize {
+			errs = append(errs, fmt.Errorf("%w: %s is %d bytes", ErrorAssetTooLarge, a.Name, info.Size()))
+		}
+
+		name := normalizeAssetName(a.Name)
+
+		// check if GitHub renames the asset
+		if name != a.Name {
+			if strict {
+				errs = append(errs, fmt.Errorf("%w: %s is stored as %s", ErrorAssetNameNormalized, a.Name, name))
+			} else {
+				logrus.Warnf("asset %s is stored as %s by GitHub", a.Name, name)
+			}
+		}
+
+		// check if the asset collides with another new asset
+		if other, ok := names[name]; ok {
+			errs = append(errs, fmt.Errorf("%w: %s and %s are both stored as %s", ErrorAssetDuplicateName, other, a.Name, name))
+
+			continue
+		}
+
+		names[name] = a.Name
+
+		// check if the asset replaces an existing asset
+		if stored[name] {
+			continue
+		}
+
+		count++
+	}
+
+	if count > _maxAssets {
+		errs = append(errs, fmt.Errorf("%w: %d assets", ErrorAssetLimit, count))
+	}
+
+	return errors.Join(errs...)
+}
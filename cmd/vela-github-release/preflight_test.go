@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGithubRelease_normalizeAssetName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "app_linux-amd64.tar.gz", want: "app_linux-amd64.tar.gz"},
+		{name: "my app (linux).zip", want: "my.app.linux.zip"},
+		{name: ".hidden", want: "hidden"},
+		{name: "café.txt", want: "caf.txt"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := normalizeAssetName(test.name)
+
+			if got != test.want {
+				t.Errorf("normalizeAssetName is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_preflight(t *testing.T) {
+	// setup filesystem
+	large := filepath.Join(t.TempDir(), "large.bin")
+
+	f, err := os.Create(large)
+	if err != nil {
+		t.Fatalf("Unable to create file: %v", err)
+	}
+
+	// create a sparse file at the size limit
+	err = f.Truncate(_maxAssetSize)
+	if err != nil {
+		t.Fatalf("Unable to truncate file: %v", err)
+	}
+
+	f.Close()
+
+	existing := make([]releaseAsset, 0, _maxAssets)
+	for i := range _maxAssets - 1 {
+		existing = append(existing, releaseAsset{Name: fmt.Sprintf("asset%d", i)})
+	}
+
+	tests := []struct {
+		name     string
+		assets   []asset
+		existing []releaseAsset
+		strict   bool
+		wantErrs []error
+	}{
+		{
+			name:   "valid",
+			assets: []asset{{Name: "test1.txt", Path: "testdata/test1.txt"}},
+		},
+		{
+			name:     "too large",
+			assets:   []asset{{Name: "large.bin", Path: large}},
+			wantErrs: []error{ErrorAssetTooLarge},
+		},
+		{
+			name:     "replaces existing",
+			assets:   []asset{{Name: "asset0", Path: "testdata/test1.txt"}},
+			existing: existing,
+		},
+		{
+			name: "too many",
+			assets: []asset{
+				{Name: "test1.txt", Path: "testdata/test1.txt"},
+				{Name: "test2.txt", Path: "testdata/test2.txt"},
+			},
+			existing: existing,
+			wantErrs: []error{ErrorAssetLimit},
+		},
+		{
+			name: "duplicate",
+			assets: []asset{
+				{Name: "test1.txt", Path: "testdata/test1.txt"},
+				{Name: "test1.txt", Path: "testdata/test2.txt"},
+			},
+			wantErrs: []error{ErrorAssetDuplicateName},
+		},
+		{
+			name: "duplicate replacing existing",
+			assets: []asset{
+				{Name: "asset0", Path: "testdata/test1.txt"},
+				{Name: "asset0", Path: "testdata/test2.txt"},
+			},
+			existing: existing,
+			wantErrs: []error{ErrorAssetDuplicateName},
+		},
+		{
+			name:   "renamed",
+			assets: []asset{{Name: "test 1.txt", Path: "testdata/test1.txt"}},
+		},
+		{
+			name: "renamed strict",
+			assets: []asset{
+				{Name: "test 1.txt", Path: "testdata/test1.txt"},
+				{Name: "test.1.txt", Path: "testdata/test2.txt"},
+			},
+			strict:   true,
+			wantErrs: []error{ErrorAssetNameNormalized, ErrorAssetDuplicateName},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := preflight(test.assets, test.existing, test.strict)
+
+			if len(test.wantErrs) == 0 && err != nil {
+				t.Errorf("preflight returned err: %v", err)
+			}
+
+			for _, want := range test.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("preflight error = %v, want it to include %v", err, want)
+				}
+			}
+		})
+	}
+}
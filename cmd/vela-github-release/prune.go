@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	pruneAction = "prune"
+
+	// pruneSortDate orders releases by their creation date.
+	pruneSortDate = "date"
+	// pruneSortSemver orders releases by their tag parsed as a semantic version.
+	pruneSortSemver = "semver"
+
+	// pruneStatusDraft selects draft releases.
+	pruneStatusDraft = "draft"
+	// pruneStatusPrerelease selects published prereleases.
+	pruneStatusPrerelease = "prerelease"
+	// pruneStatusRelease selects published stable releases.
+	pruneStatusRelease = "release"
+)
+
+var (
+	// ErrorNoPrunePolicy is returned when the plugin is missing a keep or age policy for prune.
+	ErrorNoPrunePolicy = errors.New("no prune keep or older than provided")
+
+	// ErrorInvalidPruneKeep is returned when the plugin is provided a negative prune keep.
+	ErrorInvalidPruneKeep = errors.New("invalid prune keep provided")
+
+	// ErrorInvalidPrunePattern is returned when the plugin is provided a prune pattern that fails to compile.
+	ErrorInvalidPrunePattern = errors.New("invalid prune pattern provided")
+
+	// ErrorInvalidPruneSort is returned when the plugin is provided an unsupported prune sort.
+	ErrorInvalidPruneSort = errors.New("invalid prune sort provided")
+
+	// ErrorInvalidPruneStatus is returned when the plugin is provided an unsupported prune status.
+	ErrorInvalidPruneStatus = errors.New("invalid prune status provided")
+)
+
+// Prune represents the plugin configuration for Prune config information.
+type Prune struct {
+	// delete the tags of the pruned releases
+	CleanupTag bool
+	// list the releases that would be deleted without deleting them
+	DryRun bool
+	// number of the newest selected releases to keep
+	Keep int
+	// only delete selected releases older than the duration
+	OlderThan time.Duration
+	// regular expression the tags of selected releases must match
+	Pattern string
+	// order used to determine the newest releases (semver or date)
+	Sort string
+	// list of release statuses to select (draft, prerelease or release)
+	Status []string
+
+	// pattern compiled when the configuration is validated
+	pattern *regexp.Regexp
+}
+
+// status is a helper function to return the prune status of the release.
+func status(r release) string {
+	switch {
+	case r.IsDraft:
+		return pruneStatusDraft
+	case r.IsPrerelease:
+		return pruneStatusPrerelease
+	default:
+		return pruneStatusRelease
+	}
+}
+
+// selected is a helper function to check if the
+// release matches the prune pattern and statuses.
+func (p *Prune) selected(r release) bool {
+	if p.pattern != nil && !p.pattern.MatchString(r.TagName) {
+		return false
+	}
+
+	if len(p.Status) == 0 {
+		return true
+	}
+
+	for _, s := range p.Status {
+		if strings.EqualFold(s, status(r)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newer is a helper function to check if release a is
+// newer than release b in the configured sort order.
+func (p *Prune) newer(a, b release) bool {
+	if !strings.EqualFold(p.Sort, pruneSortDate) {
+		va, vb := a.Version(), b.Version()
+
+		switch {
+		case va != nil && vb != nil && !va.Equal(vb):
+			return va.GreaterThan(vb)
+		// releases with a semantic version tag are newer than those without
+		case va != nil && vb == nil:
+			return true
+		case va == nil && vb != nil:
+			return false
+		}
+	}
+
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+// prunable is a helper function to return the releases to delete
+// from the provided releases, newest first, as of the provided time.
+func (p *Prune) prunable(releases []release, now time.Time) []release {
+	var candidates []release
+
+	for _, r := range releases {
+		if p.selected(r) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	// sort the candidates from newest to oldest
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return p.newer(candidates[i], candidates[j])
+	})
+
+	var pruned []release
+
+	for i, r := range candidates {
+		// keep the newest releases
+		if i < p.Keep {
+			logrus.Debugf("keeping release %s: one of the newest %d", r.TagName, p.Keep)
+
+			continue
+		}
+
+		// keep releases younger than the age limit
+		if p.OlderThan > 0 && now.Sub(r.CreatedAt) < p.OlderThan {
+			logrus.Debugf("keeping release %s: created %s", r.TagName, r.CreatedAt.Format(time.RFC3339))
+
+			continue
+		}
+
+		pruned = append(pruned, r)
+	}
+
+	return pruned
+}
+
+// Exec formats and runs the commands for applying
+// the provided configuration to the resources.
+func (p *Prune) Exec(ctx context.Context) error {
+	logrus.Debug("running prune with provided configuration")
+
+	releases, err := listReleases(ctx, remote{}, _releaseLimit)
+	if err != nil {
+		return err
+	}
+
+	pruned := p.prunable(releases, time.Now())
+
+	if len(pruned) == 0 {
+		logrus.Info("no releases to prune")
+
+		return nil
+	}
+
+	// check if the releases should only be listed
+	if p.DryRun {
+		logrus.Infof("dry run: %d release(s) would be deleted", len(pruned))
+
+		for _, r := range pruned {
+			fmt.Printf("would delete release %s (%s, created %s)\n", r.TagName, status(r), r.CreatedAt.Format(time.RFC3339))
+		}
+
+		return nil
+	}
+
+	for _, r := range pruned {
+		d := &Delete{
+			CleanupTag: p.CleanupTag,
+			Tag:        r.TagName,
+			Yes:        true,
+		}
+
+		// run the delete command for the pruned release
+		err = d.Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.Infof("pruned %d release(s)", len(pruned))
+
+	return nil
+}
+
+// Validate verifies the Prune is properly configured.
+func (p *Prune) Validate() error {
+	logrus.Trace("validating prune configuration")
+
+	// verify a keep or age policy is provided to avoid deleting every release
+	if p.Keep == 0 && p.OlderThan == 0 {
+		return ErrorNoPrunePolicy
+	}
+
+	// verify prune keep is not negative
+	if p.Keep < 0 {
+		return fmt.Errorf("%w: %d", ErrorInvalidPruneKeep, p.Keep)
+	}
+
+	// verify prune pattern compiles
+	if len(p.Pattern) > 0 {
+		pattern, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrorInvalidPrunePattern, err)
+		}
+
+		p.pattern = pattern
+	}
+
+	// verify prune sort is supported
+	switch strings.ToLower(p.Sort) {
+	case "", pruneSortDate, pruneSortSemver:
+	default:
+		return fmt.Errorf("%w: %s (Valid sorts: %s, %s)", ErrorInvalidPruneSort, p.Sort, pruneSortSemver, pruneSortDate)
+	}
+
+	// verify prune statuses are supported
+	for _, s := range p.Status {
+		switch strings.ToLower(s) {
+		case pruneStatusDraft, pruneStatusPrerelease, pruneStatusRelease:
+		default:
+			return fmt.Errorf("%w: %s (Valid statuses: %s, %s, %s)", ErrorInvalidPruneStatus, s, pruneStatusDraft, pruneStatusPrerelease, pruneStatusRelease)
+		}
+	}
+
+	return nil
+}
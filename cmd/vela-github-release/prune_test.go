@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGithubRelease_Prune_prunable(t *testing.T) {
+	// setup types
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	day := func(n int) time.Time {
+		return now.AddDate(0, 0, -n)
+	}
+
+	releases := []release{
+		{TagName: "v1.10.0-rc.1", IsPrerelease: true, CreatedAt: day(40)},
+		{TagName: "v1.9.0-rc.1", IsPrerelease: true, CreatedAt: day(10)},
+		{TagName: "v1.10.0-rc.2", IsPrerelease: true, CreatedAt: day(35)},
+		{TagName: "v1.10.0", CreatedAt: day(30)},
+		{TagName: "v1.11.0-rc.1", IsDraft: true, CreatedAt: day(1)},
+		{TagName: "nightly-20240501", IsPrerelease: true, CreatedAt: day(31)},
+		{TagName: "nightly-20240520", IsPrerelease: true, CreatedAt: day(12)},
+	}
+
+	tests := []struct {
+		name  string
+		prune *Prune
+		want  []string
+	}{
+		{
+			name:  "keep newest by semver",
+			prune: &Prune{Keep: 1, Pattern: `-rc\.\d+$`, Status: []string{"prerelease"}},
+			want:  []string{"v1.10.0-rc.1", "v1.9.0-rc.1"},
+		},
+		{
+			name:  "keep newest by date",
+			prune: &Prune{Keep: 1, Pattern: `-rc\.\d+$`, Sort: "date", Status: []string{"prerelease"}},
+			want:  []string{"v1.10.0-rc.2", "v1.10.0-rc.1"},
+		},
+		{
+			name:  "older than",
+			prune: &Prune{OlderThan: 30 * 24 * time.Hour, Pattern: `^nightly-`},
+			want:  []string{"nightly-20240501"},
+		},
+		{
+			name:  "keep and older than",
+			prune: &Prune{Keep: 1, OlderThan: 20 * 24 * time.Hour, Status: []string{"prerelease", "draft"}},
+			want:  []string{"v1.10.0-rc.2", "v1.10.0-rc.1", "nightly-20240501"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.prune.Validate()
+			if err != nil {
+				t.Errorf("Validate returned err: %v", err)
+			}
+
+			got := test.prune.prunable(releases, now)
+
+			if len(got) != len(test.want) {
+				t.Fatalf("prunable is %v, want %v", got, test.want)
+			}
+
+			for i, r := range got {
+				if r.TagName != test.want[i] {
+					t.Errorf("prunable[%d] is %v, want %v", i, r.TagName, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGithubRelease_Prune_Exec_Error(t *testing.T) {
+	// setup types
+	p := &Prune{
+		DryRun: true,
+		Keep:   1,
+	}
+
+	err := p.Exec(t.Context())
+	if err == nil {
+		t.Errorf("Exec should have returned err")
+	}
+}
+
+func TestGithubRelease_Prune_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prune   *Prune
+		wantErr error
+	}{
+		{
+			name:  "keep",
+			prune: &Prune{Keep: 5, Sort: "semver", Status: []string{"prerelease", "draft"}},
+		},
+		{
+			name:  "older than",
+			prune: &Prune{OlderThan: time.Hour, Pattern: `^nightly-`},
+		},
+		{
+			name:    "no policy",
+			prune:   &Prune{Pattern: `^nightly-`},
+			wantErr: ErrorNoPrunePolicy,
+		},
+		{
+			name:    "negative keep",
+			prune:   &Prune{Keep: -1},
+			wantErr: ErrorInvalidPruneKeep,
+		},
+		{
+			name:    "invalid pattern",
+			prune:   &Prune{Keep: 1, Pattern: `(`},
+			wantErr: ErrorInvalidPrunePattern,
+		},
+		{
+			name:    "invalid sort",
+			prune:   &Prune{Keep: 1, Sort: "name"},
+			wantErr: ErrorInvalidPruneSort,
+		},
+		{
+			name:    "invalid status",
+			prune:   &Prune{Keep: 1, Status: []string{"stable"}},
+			wantErr: ErrorInvalidPruneStatus,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.prune.Validate()
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Validate error = %v, wantErr = %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// _releaseFields are the JSON fields requested when listing releases.
+const _releaseFields = "createdAt,isDraft,isLatest,isPrerelease,name,publishedAt,tagName"
+
+// releaseAsset represents an asset of a GitHub release returned by gh.
+type releaseAsset struct {
+	Digest string `json:"digest"`
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+}
+
+// _viewFields are the JSON fields requested when viewing a release.
+const _viewFields = "assets,body,createdAt,isDraft,isPrerelease,name,publishedAt,tagName"
+
+// release represents a GitHub release returned by gh.
+type release struct {
+	Assets       []releaseAsset `json:"assets"`
+	Body         string         `json:"body"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	IsDraft      bool           `json:"isDraft"`
+	IsLatest     bool           `json:"isLatest"`
+	IsPrerelease bool           `json:"isPrerelease"`
+	Name         string         `json:"name"`
+	PublishedAt  time.Time      `json:"publishedAt"`
+	TagName      string         `json:"tagName"`
+}
+
+// Version returns the tag of the release parsed as a
+// semantic version or nil if the tag is not valid semver.
+func (r release) Version() *semver.Version {
+	v, err := semver.NewVersion(r.TagName)
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+// listCommand is a helper function to create the command
+// to list the releases for the repository of the remote.
+func listCommand(ctx context.Context, r remote, limit int) *exec.Cmd {
+	// variable to store flags for command
+	var flags []string
+
+	// add flag for release command
+	flags = append(flags, releaseCmd)
+
+	// add flag for list command
+	flags = append(flags, listAction)
+
+	// add flags for the JSON output
+	flags = append(flags, fmt.Sprintf("--json=%s", _releaseFields), fmt.Sprintf("--limit=%d", limit))
+
+	return withRemote(exec.CommandContext(ctx, _gh, flags...), r)
+}
+
+// listReleases is a helper function to capture the releases for the
+// repository from gh, using the current repository when no repo is provided.
+func listReleases(ctx context.Context, r remote, limit int) ([]release, error) {
+	logrus.Trace("listing releases with gh")
+
+	out, err := outputCmd(listCommand(ctx, r, limit))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list releases: %w", err)
+	}
+
+	var releases []release
+
+	err = json.Unmarshal(out, &releases)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// viewCommand is a helper function to create the command to
+// view the release for the tag in the repository of the remote.
+func viewCommand(ctx context.Context, r remote, tag string) *exec.Cmd {
+	// variable to store flags for command
+	var flags []string
+
+	// add flag for release command
+	flags = append(flags, releaseCmd)
+
+	// add flag for view command
+	flags = append(flags, viewAction, tag)
+
+	// add flag for the JSON output
+	flags = append(flags, fmt.Sprintf("--json=%s", _viewFields))
+
+	return withRemote(exec.CommandContext(ctx, _gh, flags...), r)
+}
+
+// viewRelease is a helper function to capture the release for
+// the tag from gh, using the current repository when no repo is provided.
+func viewRelease(ctx context.Context, r remote, tag string) (release, error) {
+	logrus.Tracef("viewing release %s with gh", tag)
+
+	var rel release
+
+	out, err := outputCmd(viewCommand(ctx, r, tag))
+	if err != nil {
+		return rel, fmt.Errorf("unable to view release %s: %w", tag, err)
+	}
+
+	err = json.Unmarshal(out, &rel)
+	if err != nil {
+		return rel, fmt.Errorf("unable to parse release %s: %w", tag, err)
+	}
+
+	return rel, nil
+}
+
+// releaseAssets is a helper function to capture
+// the assets of the release for the tag from gh.
+func releaseAssets(ctx context.Context, tag string) ([]releaseAsset, error) {
+	r, err := viewRelease(ctx, remote{}, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Assets, nil
+}
+
+// latestStable is a helper function to return the non-draft
+// release with the highest stable semantic version tag.
+func latestStable(releases []release) (release, *semver.Version) {
+	var (
+		latest  release
+		version *semver.Version
+	)
+
+	for _, r := range releases {
+		v := r.Version()
+		if r.IsDraft || v == nil || len(v.Prerelease()) > 0 {
+			continue
+		}
+
+		if version == nil || v.GreaterThan(version) {
+			latest, version = r, v
+		}
+	}
+
+	return latest, version
+}
+
+// repoPath is a helper function to return the repository
+// in the [HOST/]OWNER/REPO format used by gh.
+func repoPath(hostname, repo string) string {
+	if len(hostname) > 0 && len(repo) > 0 {
+		return path.Join(hostname, repo)
+	}
+
+	return repo
+}
+
+// tagPrefix is a helper function to return the "v" prefix
+// used by the provided tag, if any.
+func tagPrefix(tag string) string {
+	if strings.HasPrefix(tag, "v") {
+		return "v"
+	}
+
+	return ""
+}
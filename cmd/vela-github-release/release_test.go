@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+)
+
+func TestGithubRelease_latestStable(t *testing.T) {
+	// setup types
+	releases := []release{
+		{TagName: "v1.9.4"},
+		{TagName: "v2.3.0"},
+		{TagName: "v2.4.0-rc.1", IsPrerelease: true},
+		{TagName: "v3.0.0", IsDraft: true},
+		{TagName: "nightly"},
+	}
+
+	got, version := latestStable(releases)
+
+	if got.TagName != "v2.3.0" {
+		t.Errorf("latestStable is %v, want v2.3.0", got.TagName)
+	}
+
+	if version == nil || version.String() != "2.3.0" {
+		t.Errorf("latestStable version is %v, want 2.3.0", version)
+	}
+}
+
+func TestGithubRelease_listReleases_Error(t *testing.T) {
+	_, err := listReleases(t.Context(), remote{}, 30)
+	if err == nil {
+		t.Errorf("listReleases should have returned err")
+	}
+}
+
+func TestGithubRelease_releaseAssets_Error(t *testing.T) {
+	_, err := releaseAssets(t.Context(), "v1.0.0")
+	if err == nil {
+		t.Errorf("releaseAssets should have returned err")
+	}
+}
+
+func TestGithubRelease_viewRelease_Error(t *testing.T) {
+	_, err := viewRelease(t.Context(), remote{Repo: "octocat/hello"}, "v1.0.0")
+	if err == nil {
+		t.Errorf("viewRelease should have returned err")
+	}
+}
+
+func TestGithubRelease_repoPath(t *testing.T) {
+	tests := []struct {
+		hostname string
+		repo     string
+		want     string
+	}{
+		{hostname: "", repo: "octocat/hello", want: "octocat/hello"},
+		{hostname: "git.example.com", repo: "octocat/hello", want: "git.example.com/octocat/hello"},
+		{hostname: "git.example.com", repo: "", want: ""},
+	}
+
+	for _, test := range tests {
+		got := repoPath(test.hostname, test.repo)
+
+		if got != test.want {
+			t.Errorf("repoPath(%q, %q) is %v, want %v", test.hostname, test.repo, got, test.want)
+		}
+	}
+}
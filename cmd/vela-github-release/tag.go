@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorInvalidTag is returned when the tag does not satisfy the tag policy.
+var ErrorInvalidTag = errors.New("invalid tag provided")
+
+// TagPolicy represents the plugin configuration for validating tag names.
+type TagPolicy struct {
+	// regular expression the tag must match
+	Pattern string
+	// required prefix for the tag (e.g. v)
+	Prefix string
+	// allowed prerelease identifiers for the tag (e.g. alpha, beta, rc)
+	Prerelease []string
+	// require the tag to be a valid semantic version
+	Semver bool
+}
+
+// Validate verifies the provided tag satisfies the TagPolicy.
+// All violations are reported together.
+func (p *TagPolicy) Validate(tag string) error {
+	logrus.Tracef("validating tag %q against tag policy", tag)
+
+	// check if a tag policy is provided
+	if p == nil {
+		return nil
+	}
+
+	var errs []error
+
+	// verify the tag has no surrounding whitespace
+	if strings.TrimSpace(tag) != tag {
+		errs = append(errs, fmt.Errorf("%w: %q contains leading or trailing whitespace", ErrorInvalidTag, tag))
+	}
+
+	// verify the tag has the required prefix
+	if len(p.Prefix) > 0 && !strings.HasPrefix(tag, p.Prefix) {
+		errs = append(errs, fmt.Errorf("%w: %q must start with %q", ErrorInvalidTag, tag, p.Prefix))
+	}
+
+	// check if the tag must be a semantic version
+	if p.Semver || len(p.Prerelease) > 0 {
+		version := strings.TrimPrefix(tag, p.Prefix)
+
+		// allow the conventional "v" prefix when no prefix is required
+		if len(p.Prefix) == 0 {
+			version = strings.TrimPrefix(version, "v")
+		}
+
+		v, err := semver.StrictNewVersion(version)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %q is not a valid semantic version (e.g. v1.2.3): %w", ErrorInvalidTag, tag, err))
+		}
+
+		// verify the prerelease identifier is allowed
+		if v != nil && len(v.Prerelease()) > 0 && len(p.Prerelease) > 0 {
+			id, _, _ := strings.Cut(v.Prerelease(), ".")
+
+			if !slices.Contains(p.Prerelease, id) {
+				errs = append(errs, fmt.Errorf("%w: %q has prerelease identifier %q (allowed: %s)", ErrorInvalidTag, tag, id, strings.Join(p.Prerelease, ", ")))
+			}
+		}
+	}
+
+	// check if the tag must match a pattern
+	if len(p.Pattern) > 0 {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: invalid tag pattern %q: %w", ErrorInvalidTag, p.Pattern, err))
+		} else if !re.MatchString(tag) {
+			errs = append(errs, fmt.Errorf("%w: %q does not match pattern %q", ErrorInvalidTag, tag, p.Pattern))
+		}
+	}
+
+	return errors.Join(errs...)
+}
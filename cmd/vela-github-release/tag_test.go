@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGithubRelease_TagPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *TagPolicy
+		tag     string
+		wantErr string
+	}{
+		{
+			name: "no policy",
+			tag:  "anything",
+		},
+		{
+			name: "semver",
+			p:    &TagPolicy{Semver: true},
+			tag:  "v1.2.3",
+		},
+		{
+			name:    "semver missing patch",
+			p:       &TagPolicy{Semver: true},
+			tag:     "1.2",
+			wantErr: "is not a valid semantic version",
+		},
+		{
+			name:    "trailing space",
+			p:       &TagPolicy{},
+			tag:     "v1.2.3 ",
+			wantErr: "leading or trailing whitespace",
+		},
+		{
+			name:    "missing prefix",
+			p:       &TagPolicy{Prefix: "v", Semver: true},
+			tag:     "1.2.3",
+			wantErr: `must start with "v"`,
+		},
+		{
+			name: "allowed prerelease",
+			p:    &TagPolicy{Prefix: "v", Prerelease: []string{"beta", "rc"}},
+			tag:  "v1.2.3-rc.1",
+		},
+		{
+			name:    "disallowed prerelease",
+			p:       &TagPolicy{Prerelease: []string{"beta", "rc"}},
+			tag:     "v1.2.3-alpha.1",
+			wantErr: `prerelease identifier "alpha"`,
+		},
+		{
+			name:    "pattern",
+			p:       &TagPolicy{Pattern: `^release-\d+$`},
+			tag:     "release-x",
+			wantErr: "does not match pattern",
+		},
+		{
+			name:    "invalid pattern",
+			p:       &TagPolicy{Pattern: `(`},
+			tag:     "v1.2.3",
+			wantErr: "invalid tag pattern",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.p.Validate(test.tag)
+
+			if len(test.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("Validate returned err: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrorInvalidTag) || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Validate error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_TagPolicy_Validate_Multiple(t *testing.T) {
+	// setup types
+	p := &TagPolicy{Prefix: "v", Semver: true}
+
+	err := p.Validate("1.2 ")
+	if err == nil {
+		t.Fatalf("Validate should have returned err")
+	}
+
+	for _, want := range []string{"whitespace", "must start with", "not a valid semantic version"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate error = %v, want it to contain %v", err, want)
+		}
+	}
+}
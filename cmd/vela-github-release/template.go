@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorInvalidTemplate is returned when a release title or notes template fails to render.
+var ErrorInvalidTemplate = errors.New("invalid template provided")
+
+// templateData represents the context available
+// when rendering release titles and notes.
+type templateData struct {
+	// assets resolved from the provided files
+	Assets []templateAsset
+	// build information from the Vela environment
+	Build templateBuild
+	// current date and time
+	Date time.Time
+	// repository information from the Vela environment
+	Repo templateRepo
+	// tag name parsed as a semantic version
+	Tag templateTag
+}
+
+// templateAsset represents a release asset available to templates.
+type templateAsset struct {
+	// display label of the asset
+	Label string
+	// name of the asset
+	Name string
+	// path to the asset on disk
+	Path string
+	// size of the asset in bytes
+	Size int64
+
+	// file to digest, which differs from the path for archives
+	file string
+	// digest of the file once it has been computed
+	sum *string
+}
+
+// SHA256 returns the hex encoded sha256 digest of the asset.
+// The digest is computed once, and only when a template uses it,
+// so large assets are not read unless they need to be.
+func (t templateAsset) SHA256() (string, error) {
+	if t.sum == nil {
+		return "", nil
+	}
+
+	if len(*t.sum) == 0 {
+		sum, err := fileDigest(t.file)
+		if err != nil {
+			return "", err
+		}
+
+		*t.sum = sum
+	}
+
+	return *t.sum, nil
+}
+
+// templateBuild represents the Vela build available to templates.
+type templateBuild struct {
+	Author  string
+	Branch  string
+	Commit  string
+	Event   string
+	Link    string
+	Message string
+	Number  string
+}
+
+// templateRepo represents the Vela repository available to templates.
+type templateRepo struct {
+	Branch   string
+	FullName string
+	Link     string
+	Name     string
+	Org      string
+}
+
+// templateTag represents the release tag available to templates.
+type templateTag struct {
+	Major      uint64
+	Metadata   string
+	Minor      uint64
+	Name       string
+	Patch      uint64
+	Prerelease string
+}
+
+// String returns the tag name so {{ .Tag }} renders as written.
+func (t templateTag) String() string {
+	return t.Name
+}
+
+// newTemplateData is a helper function to create the template
+// context from the Vela environment, tag and assets.
+func newTemplateData(tag string, assets []asset) (*templateData, error) {
+	logrus.Trace("creating template data from plugin configuration")
+
+	data := &templateData{
+		Build: templateBuild{
+			Author:  os.Getenv("VELA_BUILD_AUTHOR"),
+			Branch:  os.Getenv("VELA_BUILD_BRANCH"),
+			Commit:  os.Getenv("VELA_BUILD_COMMIT"),
+			Event:   os.Getenv("VELA_BUILD_EVENT"),
+			Link:    os.Getenv("VELA_BUILD_LINK"),
+			Message: os.Getenv("VELA_BUILD_MESSAGE"),
+			Number:  os.Getenv("VELA_BUILD_NUMBER"),
+		},
+		Date: time.Now().UTC(),
+		Repo: templateRepo{
+			Branch:   os.Getenv("VELA_REPO_BRANCH"),
+			FullName: os.Getenv("VELA_REPO_FULL_NAME"),
+			Link:     os.Getenv("VELA_REPO_LINK"),
+			Name:     os.Getenv("VELA_REPO_NAME"),
+			Org:      os.Getenv("VELA_REPO_ORG"),
+		},
+		Tag: templateTag{
+			Name: tag,
+		},
+	}
+
+	// parse the tag as a semantic version when possible
+	v, err := semver.NewVersion(tag)
+	if err == nil {
+		data.Tag.Major = v.Major()
+		data.Tag.Minor = v.Minor()
+		data.Tag.Patch = v.Patch()
+		data.Tag.Prerelease = v.Prerelease()
+		data.Tag.Metadata = v.Metadata()
+	}
+
+	// iterate through the assets and capture their details
+	for _, asset := range assets {
+		t := templateAsset{
+			Label: asset.Label,
+			Name:  asset.Name,
+			Path:  asset.Path,
+			file:  asset.Path,
+		}
+
+		// archives are built in the stage directory
+		if len(asset.Archive) > 0 {
+			t.file = asset.File()
+		}
+
+		info, err := os.Stat(t.file)
+
+		// archives are missing until the assets are staged
+		if err != nil && len(asset.Archive) > 0 {
+			logrus.Debugf("unable to read archive %s: %v", t.file, err)
+
+			data.Assets = append(data.Assets, t)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.Size = info.Size()
+		t.sum = new(string)
+
+		data.Assets = append(data.Assets, t)
+	}
+
+	return data, nil
+}
+
+// fileDigest is a helper function to return the hex encoded
+// sha256 digest of the file at the provided path, streaming the
+// file so large assets are not read into memory.
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isTemplate is a helper function to check if
+// any of the provided values contain template actions.
+func isTemplate(values ...string) bool {
+	for _, value := range values {
+		if strings.Contains(value, "{{") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTemplate is a helper function to parse the
+// provided text as a Go template without rendering it.
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrorInvalidTemplate, name, err)
+	}
+
+	return tmpl, nil
+}
+
+// renderTemplate is a helper function to render the
+// provided text as a Go template with the provided data.
+func renderTemplate(name, text string, data *templateData) (string, error) {
+	logrus.Tracef("rendering %s template", name)
+
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrorInvalidTemplate, name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGithubRelease_newTemplateData(t *testing.T) {
+	// setup environment
+	t.Setenv("VELA_BUILD_NUMBER", "42")
+	t.Setenv("VELA_REPO_FULL_NAME", "go-vela/vela-github-release")
+
+	got, err := newTemplateData("v1.2.3-rc.1", []asset{{Name: "test1.txt", Path: "testdata/test1.txt"}})
+	if err != nil {
+		t.Errorf("newTemplateData returned err: %v", err)
+	}
+
+	if got.Build.Number != "42" {
+		t.Errorf("Build.Number is %v, want 42", got.Build.Number)
+	}
+
+	if got.Repo.FullName != "go-vela/vela-github-release" {
+		t.Errorf("Repo.FullName is %v, want go-vela/vela-github-release", got.Repo.FullName)
+	}
+
+	if got.Tag.Major != 1 || got.Tag.Minor != 2 || got.Tag.Patch != 3 || got.Tag.Prerelease != "rc.1" {
+		t.Errorf("Tag is %+v, want 1.2.3-rc.1", got.Tag)
+	}
+
+	if len(got.Assets) != 1 {
+		t.Fatalf("Assets length is %v, want 1", len(got.Assets))
+	}
+
+	if got.Assets[0].Name != "test1.txt" || got.Assets[0].Size != 6 {
+		t.Errorf("Assets[0] is %+v, want test1.txt with size 6", got.Assets[0])
+	}
+
+	if *got.Assets[0].sum != "" {
+		t.Errorf("Assets[0].SHA256 should not be computed before it is used")
+	}
+
+	sum, err := got.Assets[0].SHA256()
+	if err != nil {
+		t.Errorf("Assets[0].SHA256 returned err: %v", err)
+	}
+
+	if sum != digest([]byte("test1\n")) {
+		t.Errorf("Assets[0].SHA256 is %v, want %v", sum, digest([]byte("test1\n")))
+	}
+}
+
+func TestGithubRelease_renderTemplate(t *testing.T) {
+	// setup types
+	sum := "abc123"
+
+	data := &templateData{
+		Assets: []templateAsset{
+			{Name: "app", Size: 10, sum: &sum},
+		},
+		Build: templateBuild{Number: "42"},
+		Tag:   templateTag{Name: "v1.2.3", Major: 1, Minor: 2, Patch: 3},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "tag",
+			text: "Release {{ .Tag }} (build {{ .Build.Number }})",
+			want: "Release v1.2.3 (build 42)",
+		},
+		{
+			name: "semver",
+			text: "{{ .Tag.Major }}.{{ .Tag.Minor }}",
+			want: "1.2",
+		},
+		{
+			name: "assets",
+			text: "{{ range .Assets }}{{ .Name }} {{ .Size }} {{ .SHA256 }}{{ end }}",
+			want: "app 10 abc123",
+		},
+		{
+			name:    "parse error",
+			text:    "{{ .Tag",
+			wantErr: ErrorInvalidTemplate,
+		},
+		{
+			name:    "unknown field",
+			text:    "{{ .Build.Nmber }}",
+			wantErr: ErrorInvalidTemplate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderTemplate(test.name, test.text, data)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("renderTemplate error = %v, wantErr = %v", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("renderTemplate is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
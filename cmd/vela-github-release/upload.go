@@ -6,8 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
-	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -21,10 +22,33 @@ var ErrorNoUploadTag = errors.New("no upload tag provided")
 type Upload struct {
 	// list of asset files to be given to upload
 	Files []string
+	// format to archive directories in before upload (tar.gz or zip)
+	Archive string
+	// template for the name of archived directories
+	ArchiveName string
+	// name of a release asset listing sha256 digests for assets without a recorded digest
+	ChecksumManifest string
 	// overwrite existing assets of the same name
 	Clobber bool
+	// list of patterns for files to exclude from the assets
+	Exclude []string
+	// policy the tag must satisfy
+	Policy *TagPolicy
+	// only upload assets that are new or differ from the existing assets
+	SkipUnchanged bool
+	// directory to stage renamed assets in
+	StageDir string
+	// fail on unmatched patterns, directories, empty files and duplicate asset names
+	Strict bool
 	// tag name to upload a release from
 	Tag string
+
+	// assets resolved outside of the files, such as the assets of a mirrored release
+	assets []asset
+	// assets resolved from the files
+	files []asset
+	// names of the assets matching the existing assets of the release
+	unchanged map[string]bool
 }
 
 // Command formats and outputs the Upload command from
@@ -47,20 +71,14 @@ func (u *Upload) Command(ctx context.Context) *exec.Cmd {
 		flags = append(flags, u.Tag)
 	}
 
-	// iterate through the files and add them as parameters
-	for _, file := range u.Files {
-		f, err := filepath.Glob(file)
-		if err != nil {
-			logrus.Warnf("bad file pattern: %v", err)
-		}
-
-		if f == nil {
-			logrus.Warnf("no file matches found for %s", file)
-
+	// add the assets resolved from the provided files as parameters
+	for _, a := range append(u.files, u.assets...) {
+		// skip assets matching the existing assets
+		if u.unchanged[a.Name] {
 			continue
 		}
 
-		flags = append(flags, f...)
+		flags = append(flags, a.Arg())
 	}
 
 	// add flag for upload from provided upload
@@ -69,16 +87,84 @@ func (u *Upload) Command(ctx context.Context) *exec.Cmd {
 	return exec.CommandContext(ctx, _gh, flags...)
 }
 
+// ManifestCommand formats and outputs the command to
+// print the checksum manifest of the release to stdout.
+func (u *Upload) ManifestCommand(ctx context.Context) *exec.Cmd {
+	logrus.Trace("creating gh download command for the checksum manifest")
+
+	// variable to store flags for command
+	var flags []string
+
+	// add flag for release command
+	flags = append(flags, releaseCmd)
+
+	// add flag for download command
+	flags = append(flags, downloadAction)
+
+	// add flag for tag from provided upload tag
+	flags = append(flags, u.Tag)
+
+	// add flag for pattern from provided upload checksum manifest
+	flags = append(flags, fmt.Sprintf("--pattern=%s", u.ChecksumManifest))
+
+	// add flag to write the manifest to stdout
+	flags = append(flags, "--output=-")
+
+	return exec.CommandContext(ctx, _gh, flags...)
+}
+
 // Exec formats and runs the commands for applying
 // the provided configuration to the resources.
 func (u *Upload) Exec(ctx context.Context) error {
 	logrus.Debug("running upload with the provided configuration")
 
+	err := u.resolve()
+	if err != nil {
+		return err
+	}
+
+	// link renamed assets and archive directories under their asset name
+	err = stageAssets(u.files)
+	if err != nil {
+		return err
+	}
+
+	existing, err := releaseAssets(ctx, u.Tag)
+	if err != nil {
+		return err
+	}
+
+	// verify the assets fit the GitHub limits alongside the existing assets before uploading
+	err = preflight(u.files, existing, u.Strict)
+	if err != nil {
+		return err
+	}
+
+	// check if unchanged assets should be skipped
+	if u.SkipUnchanged {
+		manifest, err := u.manifest(ctx, existing)
+		if err != nil {
+			return err
+		}
+
+		u.unchanged, err = unchangedAssets(u.files, existing, manifest)
+		if err != nil {
+			return err
+		}
+
+		// check if there is anything left to upload
+		if len(u.unchanged) == len(u.files) {
+			logrus.Info("all assets are unchanged, skipping upload")
+
+			return nil
+		}
+	}
+
 	// upload command for the existing asset
 	cmd := u.Command(ctx)
 
 	// run the upload command for the existng asset
-	err := execCmd(cmd, nil)
+	err = execCmd(cmd, nil)
 	if err != nil {
 		return err
 	}
@@ -86,6 +172,136 @@ func (u *Upload) Exec(ctx context.Context) error {
 	return nil
 }
 
+// manifest is a helper function to return the digests listed in the
+// checksum manifest of the release, when it is provided and exists.
+func (u *Upload) manifest(ctx context.Context, existing []releaseAsset) (map[string]string, error) {
+	// check if upload checksum manifest is provided
+	if len(u.ChecksumManifest) == 0 {
+		return nil, nil
+	}
+
+	for _, e := range existing {
+		if e.Name != u.ChecksumManifest {
+			continue
+		}
+
+		out, err := outputCmd(u.ManifestCommand(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return parseChecksums(out), nil
+	}
+
+	logrus.Infof("checksum manifest %s not found in release %s", u.ChecksumManifest, u.Tag)
+
+	return nil, nil
+}
+
+// parseChecksums is a helper function to return the digests by
+// asset name from a manifest in the sha256sum format.
+func parseChecksums(b []byte) map[string]string {
+	digests := make(map[string]string)
+
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		// names of files read in binary mode are prefixed with an asterisk
+		digests[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+
+	return digests
+}
+
+// unchangedAssets is a helper function to return the names of the assets
+// with the same content as the existing asset of the same name. Assets are
+// compared by size, then by the digest recorded by GitHub or listed in the
+// checksum manifest. Assets without any digest are treated as changed.
+func unchangedAssets(assets []asset, existing []releaseAsset, manifest map[string]string) (map[string]bool, error) {
+	remotes := make(map[string]releaseAsset)
+
+	for _, e := range existing {
+		remotes[e.Name] = e
+	}
+
+	unchanged := make(map[string]bool)
+
+	for _, asset := range assets {
+		remote, ok := remotes[normalizeAssetName(asset.Name)]
+		if !ok {
+			logrus.Infof("uploading new asset %s", asset.Name)
+
+			continue
+		}
+
+		info, err := os.Stat(asset.File())
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Size() != remote.Size {
+			logrus.Infof("uploading changed asset %s: size %d differs from %d", asset.Name, info.Size(), remote.Size)
+
+			continue
+		}
+
+		sum := strings.TrimPrefix(remote.Digest, "sha256:")
+
+		// assets uploaded before GitHub recorded digests fall back to the checksum manifest
+		if len(sum) == 0 {
+			sum = manifest[remote.Name]
+		}
+
+		// assets without any digest can't be shown to be unchanged
+		if len(sum) == 0 {
+			logrus.Infof("uploading asset %s: no digest is recorded to compare against", asset.Name)
+
+			continue
+		}
+
+		local, err := fileDigest(asset.File())
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.EqualFold(local, sum) {
+			logrus.Infof("uploading changed asset %s: digest %s differs from %s", asset.Name, local, sum)
+
+			continue
+		}
+
+		logrus.Infof("skipping unchanged asset %s: digest %s", asset.Name, local)
+
+		unchanged[asset.Name] = true
+	}
+
+	return unchanged, nil
+}
+
+// assetOptions returns the options for resolving the upload assets.
+func (u *Upload) assetOptions() assetOptions {
+	return assetOptions{
+		Archive:     u.Archive,
+		ArchiveName: u.ArchiveName,
+		Exclude:     u.Exclude,
+		StageDir:    u.StageDir,
+		Strict:      u.Strict,
+		Tag:         u.Tag,
+	}
+}
+
+// resolve resolves the provided files into the assets to upload.
+func (u *Upload) resolve() error {
+	var err error
+
+	u.files, err = resolveAssets(u.Files, u.assetOptions())
+
+	return err
+}
+
 // Validate verifies the Upload is properly configured.
 func (u *Upload) Validate() error {
 	logrus.Trace("validating upload configuration")
@@ -95,5 +311,18 @@ func (u *Upload) Validate() error {
 		return ErrorNoUploadTag
 	}
 
-	return nil
+	// verify the archive format is supported
+	err := validateArchive(u.Archive)
+	if err != nil {
+		return err
+	}
+
+	// verify the files resolve to assets
+	_, err = resolveAssets(u.Files, u.assetOptions())
+	if err != nil {
+		return err
+	}
+
+	// verify upload tag satisfies the tag policy
+	return u.Policy.Validate(u.Tag)
 }
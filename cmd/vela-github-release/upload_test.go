@@ -28,6 +28,12 @@ func TestGithubRelease_Upload_Command(t *testing.T) {
 		fmt.Sprintf("--clobber=%t", u.Clobber),
 	)
 
+	// resolve the assets from the files
+	err := u.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := u.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -63,6 +69,12 @@ func TestGithubRelease_Upload_Command_FileMissing(t *testing.T) {
 		fmt.Sprintf("--clobber=%t", u.Clobber),
 	)
 
+	// resolve the assets from the files
+	err := u.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := u.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -100,6 +112,12 @@ func TestGithubRelease_Upload_Command_MultipleFiles(t *testing.T) {
 		fmt.Sprintf("--clobber=%t", u.Clobber),
 	)
 
+	// resolve the assets from the files
+	err := u.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := u.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -137,6 +155,12 @@ func TestGithubRelease_Upload_Command_MultipleFilesGlob(t *testing.T) {
 		fmt.Sprintf("--clobber=%t", u.Clobber),
 	)
 
+	// resolve the assets from the files
+	err := u.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
 	got := u.Command(t.Context())
 
 	if got.Path != want.Path {
@@ -199,3 +223,159 @@ func TestGithubRelease_Upload_Validate_Error(t *testing.T) {
 		t.Errorf("Validate should have returned err: %v, instead returned %v", ErrorNoUploadTag, err)
 	}
 }
+
+func TestGithubRelease_Upload_Validate_Policy(t *testing.T) {
+	// setup types
+	u := &Upload{
+		Files:  []string{"files"},
+		Policy: &TagPolicy{Prefix: "v"},
+		Tag:    "1.2.3",
+	}
+
+	err := u.Validate()
+	if !errors.Is(err, ErrorInvalidTag) {
+		t.Errorf("Validate should have returned err: %v, instead returned %v", ErrorInvalidTag, err)
+	}
+}
+
+func TestGithubRelease_unchangedAssets(t *testing.T) {
+	// setup types
+	assets := []asset{
+		{Name: "test1.txt", Path: "testdata/test1.txt"},
+		{Name: "test2.txt", Path: "testdata/test2.txt"},
+		{Name: "file", Path: "testdata/file"},
+		{Name: "new.txt", Path: "testdata/test1.txt"},
+		{Name: "resized.txt", Path: "testdata/test1.txt"},
+		{Name: "listed.txt", Path: "testdata/test1.txt"},
+		{Name: "stale.txt", Path: "testdata/test2.txt"},
+	}
+
+	existing := []releaseAsset{
+		{Name: "test1.txt", Digest: "sha256:634b027b1b69e1242d40d53e312b3b4ac7710f55be81f289b549446ef6778bee", Size: 6},
+		{Name: "test2.txt", Digest: "sha256:634b027b1b69e1242d40d53e312b3b4ac7710f55be81f289b549446ef6778bee", Size: 6},
+		{Name: "file", Size: 8},
+		{Name: "resized.txt", Size: 7},
+		{Name: "listed.txt", Size: 6},
+		{Name: "stale.txt", Size: 6},
+	}
+
+	manifest := map[string]string{
+		"listed.txt": "634b027b1b69e1242d40d53e312b3b4ac7710f55be81f289b549446ef6778bee",
+		"stale.txt":  "634b027b1b69e1242d40d53e312b3b4ac7710f55be81f289b549446ef6778bee",
+	}
+
+	// link the renamed assets in the stage directory
+	dir := t.TempDir()
+
+	for i := range assets {
+		assets[i].stage = dir
+	}
+
+	err := stageAssets(assets)
+	if err != nil {
+		t.Errorf("stageAssets returned err: %v", err)
+	}
+
+	got, err := unchangedAssets(assets, existing, manifest)
+	if err != nil {
+		t.Errorf("unchangedAssets returned err: %v", err)
+	}
+
+	want := map[string]bool{"test1.txt": true, "listed.txt": true}
+
+	if len(got) != len(want) {
+		t.Errorf("unchangedAssets is %v, want %v", got, want)
+	}
+
+	for name := range want {
+		if !got[name] {
+			t.Errorf("unchangedAssets is %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGithubRelease_parseChecksums(t *testing.T) {
+	got := parseChecksums([]byte("abc123  app_linux.tar.gz\ndef456 *app_windows.zip\n\ninvalid\n"))
+
+	want := map[string]string{"app_linux.tar.gz": "abc123", "app_windows.zip": "def456"}
+
+	if len(got) != len(want) {
+		t.Errorf("parseChecksums is %v, want %v", got, want)
+	}
+
+	for name, sum := range want {
+		if got[name] != sum {
+			t.Errorf("parseChecksums[%s] is %v, want %v", name, got[name], sum)
+		}
+	}
+}
+
+func TestGithubRelease_Upload_ManifestCommand(t *testing.T) {
+	// setup types
+	u := &Upload{
+		ChecksumManifest: "checksums.txt",
+		Tag:              "tag",
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		downloadAction,
+		"tag",
+		"--pattern=checksums.txt",
+		"--output=-",
+	)
+
+	got := u.ManifestCommand(t.Context())
+
+	if len(got.Args) != len(want.Args) {
+		t.Fatalf("Command args is %v, want %v", got.Args, want.Args)
+	}
+
+	for i, arg := range got.Args {
+		if arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
+
+func TestGithubRelease_Upload_Command_SkipUnchanged(t *testing.T) {
+	// setup types
+	u := &Upload{
+		Files:         []string{"testdata/*.txt"},
+		SkipUnchanged: true,
+		Tag:           "tag",
+		unchanged:     map[string]bool{"test1.txt": true},
+	}
+
+	//nolint:gosec // ignore for testing purposes
+	want := exec.CommandContext(
+		t.Context(),
+		_gh,
+		releaseCmd,
+		uploadAction,
+		"tag",
+		"testdata/test2.txt",
+		fmt.Sprintf("--clobber=%t", u.Clobber),
+	)
+
+	// resolve the assets from the files
+	err := u.resolve()
+	if err != nil {
+		t.Errorf("resolve returned err: %v", err)
+	}
+
+	got := u.Command(t.Context())
+
+	if len(got.Args) != len(want.Args) {
+		t.Fatalf("Command args is %v, want %v", got.Args, want.Args)
+	}
+
+	for i, arg := range got.Args {
+		if arg != want.Args[i] {
+			t.Errorf("Command args[%d] is %v, want %v", i, arg, want.Args[i])
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// _maxTagDepth is the maximum number of annotated tags to follow to reach a commit.
+const _maxTagDepth = 5
+
+var (
+	// ErrorCreateTagNotFound is returned when the tag does not exist in the remote repository.
+	ErrorCreateTagNotFound = errors.New("tag not found in remote repository")
+
+	// ErrorCreateTagMismatch is returned when the tag does not point at the create target commit.
+	ErrorCreateTagMismatch = errors.New("tag does not point at target commit")
+
+	// shaRegex matches a full or abbreviated commit SHA.
+	shaRegex = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+)
+
+// gitObject is a helper function to capture the type and SHA
+// of the object selected by the provided query from gh.
+func gitObject(ctx context.Context, endpoint, query string) (string, string, error) {
+	out, err := outputCmd(exec.CommandContext(ctx, _gh, "api", endpoint, "--jq", query+` | .object.type + " " + .object.sha`))
+	if err != nil {
+		return "", "", err
+	}
+
+	kind, sha, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+
+	return kind, sha, nil
+}
+
+// tagCommit is a helper function to resolve the provided
+// tag in the remote repository to the commit it points at.
+func tagCommit(ctx context.Context, tag string) (string, error) {
+	logrus.Tracef("resolving remote tag %s with gh", tag)
+
+	// matching refs lists no refs rather than failing with a 404 for a
+	// missing tag, so any error is a failure to reach the repository
+	endpoint := fmt.Sprintf("repos/{owner}/{repo}/git/matching-refs/tags/%s", tag)
+
+	kind, sha, err := gitObject(ctx, endpoint, fmt.Sprintf(".[] | select(.ref == %s)", strconv.Quote("refs/tags/"+tag)))
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve tag %s: %w", tag, err)
+	}
+
+	if len(sha) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrorCreateTagNotFound, tag)
+	}
+
+	// follow annotated tags to the commit they point at
+	for i := 0; kind == "tag" && i < _maxTagDepth; i++ {
+		kind, sha, err = gitObject(ctx, fmt.Sprintf("repos/{owner}/{repo}/git/tags/%s", sha), ".")
+		if err != nil {
+			return "", fmt.Errorf("unable to resolve annotated tag %s: %w", tag, err)
+		}
+	}
+
+	if kind != "commit" {
+		return "", fmt.Errorf("%w: %s points at a %s", ErrorCreateTagMismatch, tag, kind)
+	}
+
+	return sha, nil
+}
+
+// verifyTag verifies the tag exists in the remote repository
+// and points at the target when the target is a commit SHA.
+func verifyTag(ctx context.Context, tag, target string) error {
+	logrus.Debugf("verifying tag %s exists in remote repository", tag)
+
+	sha, err := tagCommit(ctx, tag)
+	if err != nil {
+		return err
+	}
+
+	// check if the target is a commit SHA
+	if !shaRegex.MatchString(target) {
+		logrus.Debugf("target %s is not a commit SHA, skipping commit verification", target)
+
+		return nil
+	}
+
+	if !strings.HasPrefix(strings.ToLower(sha), strings.ToLower(target)) {
+		return fmt.Errorf("%w: %s points at %s, want %s", ErrorCreateTagMismatch, tag, sha, target)
+	}
+
+	logrus.Infof("verified tag %s points at %s", tag, sha)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGithubRelease_shaRegex(t *testing.T) {
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{target: "main", want: false},
+		{target: "release/1.x", want: false},
+		{target: "abc1234", want: true},
+		{target: "55cd717e0e1f4f1f8e3a2b0c9d8e7f6a5b4c3d2e", want: true},
+		{target: "abc12", want: false},
+		{target: "deadbeefcafe-branch", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			if got := shaRegex.MatchString(test.target); got != test.want {
+				t.Errorf("shaRegex match is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGithubRelease_verifyTag_Error(t *testing.T) {
+	err := verifyTag(t.Context(), "v1.2.3", "abc1234")
+	if err == nil {
+		t.Errorf("verifyTag should have returned err")
+	}
+
+	// failing to run gh is not a missing tag
+	if errors.Is(err, ErrorCreateTagNotFound) {
+		t.Errorf("verifyTag error = %v, should not be %v", err, ErrorCreateTagNotFound)
+	}
+}